@@ -0,0 +1,83 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+type auditResourceKey struct{}
+
+// AuditRecord is one line of the audit trail written to AuditLogPath for every API mutation
+type AuditRecord struct {
+	Timestamp      string          `json:"timestamp"`
+	Method         string          `json:"method"`
+	Endpoint       string          `json:"endpoint"`
+	Resource       string          `json:"resource,omitempty"`
+	IdempotencyKey string          `json:"idempotency_key,omitempty"`
+	StatusCode     int             `json:"status_code"`
+	Error          string          `json:"error,omitempty"`
+	RequestBody    json.RawMessage `json:"request_body,omitempty"`
+}
+
+// WithAuditResource returns a context that attributes the API mutations made with it to a resource in the audit trail
+func WithAuditResource(ctx context.Context, resource string) context.Context {
+	return context.WithValue(ctx, auditResourceKey{}, resource)
+}
+
+// CheckAuditLogPath verifies that the audit trail file can be created and appended to
+func CheckAuditLogPath(path string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening audit_log_path %s: %s", path, err)
+	}
+	return file.Close()
+}
+
+// audit appends a record for a mutating request to the audit trail, with secrets redacted from the body.
+// Failing to write the trail is logged but does not fail the request, which has already been sent.
+func (c *Config) audit(ctx context.Context, method, endpoint, idempotencyKey string, body []byte, statusCode int, requestErr error) {
+	if c.AuditLogPath == "" || method == "GET" {
+		return
+	}
+
+	record := AuditRecord{
+		Timestamp:      time.Now().UTC().Format(time.RFC3339Nano),
+		Method:         method,
+		Endpoint:       endpoint,
+		IdempotencyKey: idempotencyKey,
+		StatusCode:     statusCode,
+	}
+	if resource, ok := ctx.Value(auditResourceKey{}).(string); ok {
+		record.Resource = resource
+	}
+	if requestErr != nil {
+		record.Error = requestErr.Error()
+	}
+	if redacted := RedactSecrets(string(body), c.APIKey); json.Valid([]byte(redacted)) && redacted != "null" {
+		record.RequestBody = json.RawMessage(redacted)
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		log.Printf("[ERROR] Error encoding audit record for %s %s: %v", method, endpoint, err)
+		return
+	}
+
+	c.auditMu.Lock()
+	defer c.auditMu.Unlock()
+
+	file, err := os.OpenFile(c.AuditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		log.Printf("[ERROR] Error opening audit log %s: %v", c.AuditLogPath, err)
+		return
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		log.Printf("[ERROR] Error writing audit log %s: %v", c.AuditLogPath, err)
+	}
+}
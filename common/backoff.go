@@ -0,0 +1,61 @@
+package common
+
+import (
+	"math/rand"
+	"time"
+)
+
+// The backoff strategies used between retries of rate-limited requests
+const (
+	BackoffExponential       = "exponential"        // The retry interval, doubled after every retry
+	BackoffExponentialJitter = "exponential_jitter" // As exponential, plus a random jitter of up to 1 second
+	BackoffConstant          = "constant"           // The retry interval before every retry
+	BackoffDecorrelated      = "decorrelated"       // A random wait between the retry interval and 3 times the previous wait
+)
+
+// BackoffStrategies lists the backoff strategies accepted by the provider
+var BackoffStrategies = []string{BackoffExponential, BackoffExponentialJitter, BackoffConstant, BackoffDecorrelated}
+
+// maxBackoffJitter is the largest random jitter added to the wait by the exponential_jitter strategy
+const maxBackoffJitter = time.Second
+
+// unlimitedBackoff stops the exponential strategies from doubling the wait further when MaxBackoff is not set, so
+// many retries cannot overflow it
+const unlimitedBackoff = 24 * time.Hour
+
+// backoffWait returns how long to wait before the next attempt of a request, given the retry interval, the number
+// of retries already made for the request, and the previous wait. The wait is capped at MaxBackoff when it is set.
+func (c *Config) backoffWait(interval time.Duration, retries int, previous time.Duration) time.Duration {
+	var wait time.Duration
+	switch c.BackoffStrategy {
+	case BackoffConstant:
+		wait = interval
+	case BackoffDecorrelated:
+		// Decorrelated jitter: spread the retries of concurrent requests while still growing the wait
+		upper := 3 * previous
+		if upper <= interval {
+			upper = 3 * interval
+		}
+		wait = interval
+		if upper > interval {
+			wait += time.Duration(rand.Int63n(int64(upper - interval)))
+		}
+	default:
+		limit := c.MaxBackoff
+		if limit <= 0 {
+			limit = unlimitedBackoff
+		}
+		wait = interval
+		for i := 0; i < retries && wait < limit; i++ {
+			wait *= 2
+		}
+		if c.BackoffStrategy != BackoffExponential {
+			wait += time.Duration(rand.Int63n(int64(maxBackoffJitter)))
+		}
+	}
+
+	if c.MaxBackoff > 0 && wait > c.MaxBackoff {
+		wait = c.MaxBackoff
+	}
+	return wait
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// maxWhitelistBatchSize caps the number of entries sent in one coalesced whitelist-add request
+const maxWhitelistBatchSize = 500
+
+type whitelistBatch struct {
+	ctx     context.Context // Values of the caller that opened the batch, without its cancellation
+	entries []map[string]interface{}
+	waiters []chan error
+	timer   *time.Timer
+}
+
+// whitelistBatcher groups whitelist-add calls for the same account made within a short window into a
+// single API request. Each caller blocks until the request carrying its entry has completed.
+type whitelistBatcher struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending map[string]*whitelistBatch
+	flush   func(ctx context.Context, accountName string, entries []map[string]interface{}) ([]byte, error)
+}
+
+func newWhitelistBatcher(window time.Duration, flush func(ctx context.Context, accountName string, entries []map[string]interface{}) ([]byte, error)) *whitelistBatcher {
+	return &whitelistBatcher{
+		window:  window,
+		pending: make(map[string]*whitelistBatch),
+		flush:   flush,
+	}
+}
+
+// add queues an entry for the account and waits for the batched request to complete, or for ctx to be cancelled
+func (b *whitelistBatcher) add(ctx context.Context, accountName string, entry map[string]interface{}) error {
+	result := make(chan error, 1)
+
+	b.mu.Lock()
+	batch, ok := b.pending[accountName]
+	if !ok {
+		// The batch is sent for all its callers, so one cancelled caller must not cancel the request of the others
+		batch = &whitelistBatch{ctx: context.WithoutCancel(ctx)}
+		b.pending[accountName] = batch
+		batch.timer = time.AfterFunc(b.window, func() { b.send(accountName, batch) })
+	}
+	batch.entries = append(batch.entries, entry)
+	batch.waiters = append(batch.waiters, result)
+
+	// Close full batches, so later adds open a new one, and send them immediately rather than waiting for the
+	// window to close. If the timer already fired, its send is waiting for the lock and sends the batch.
+	if len(batch.entries) >= maxWhitelistBatchSize {
+		delete(b.pending, accountName)
+		if batch.timer.Stop() {
+			go b.send(accountName, batch)
+		}
+	}
+	b.mu.Unlock()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *whitelistBatcher) send(accountName string, batch *whitelistBatch) {
+	// Once the batch is no longer pending no entries are added to it, so it can be read without the lock
+	b.mu.Lock()
+	if b.pending[accountName] == batch {
+		delete(b.pending, accountName)
+	}
+	b.mu.Unlock()
+
+	// When the API reports per-item results, only the callers whose entry was rejected get an error
+	responseBody, err := b.flush(batch.ctx, accountName, batch.entries)
+	for i, waiter := range batch.waiters {
+		waiter <- whitelistAddResult(batch.entries[i], responseBody, err)
+	}
+}
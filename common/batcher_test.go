@@ -0,0 +1,129 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingFlush records the batches sent by a whitelist batcher, and blocks each send until release is closed when
+// it is set
+type recordingFlush struct {
+	mu      sync.Mutex
+	batches []int
+	ctxErrs []error
+	release chan struct{}
+}
+
+func (f *recordingFlush) flush(ctx context.Context, accountName string, entries []map[string]interface{}) ([]byte, error) {
+	if f.release != nil {
+		<-f.release
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batches = append(f.batches, len(entries))
+	f.ctxErrs = append(f.ctxErrs, ctx.Err())
+	return []byte(`{}`), nil
+}
+
+func (f *recordingFlush) sizes() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sizes := append([]int(nil), f.batches...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+	return sizes
+}
+
+// addConcurrently adds count entries to the account at once and returns the error of each add
+func addConcurrently(b *whitelistBatcher, count int) []error {
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = b.add(context.Background(), "printers", map[string]interface{}{"Mac": fmt.Sprintf("00:00:00:00:%02X:%02X", i/256, i%256)})
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestWhitelistBatcherWindow(t *testing.T) {
+	f := &recordingFlush{}
+	b := newWhitelistBatcher(50*time.Millisecond, f.flush)
+
+	for i, err := range addConcurrently(b, 10) {
+		if err != nil {
+			t.Errorf("add %d: %v", i, err)
+		}
+	}
+	if got := fmt.Sprint(f.sizes()); got != "[10]" {
+		t.Errorf("batches = %s, want the 10 adds of the window in one batch", got)
+	}
+
+	// An add after the window closed opens a new batch
+	if err := b.add(context.Background(), "printers", map[string]interface{}{"Mac": "00:00:00:00:10:00"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(f.sizes()); got != "[10 1]" {
+		t.Errorf("batches = %s, want a second batch of 1", got)
+	}
+}
+
+func TestWhitelistBatcherCap(t *testing.T) {
+	f := &recordingFlush{}
+	// The window is long enough that only the cap can send the full batch in time
+	b := newWhitelistBatcher(time.Second, f.flush)
+
+	start := time.Now()
+	errs := addConcurrently(b, maxWhitelistBatchSize+20)
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("add %d: %v", i, err)
+		}
+	}
+	if got, want := fmt.Sprint(f.sizes()), fmt.Sprint([]int{maxWhitelistBatchSize, 20}); got != want {
+		t.Errorf("batches = %s, want %s", got, want)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("the adds of the second batch returned after %s, before its window closed", elapsed)
+	}
+}
+
+func TestWhitelistBatcherCancellation(t *testing.T) {
+	f := &recordingFlush{release: make(chan struct{})}
+	b := newWhitelistBatcher(20*time.Millisecond, f.flush)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		cancelled <- b.add(ctx, "printers", map[string]interface{}{"Mac": "00:00:00:00:00:01"})
+	}()
+	other := make(chan error, 1)
+	go func() {
+		other <- b.add(context.Background(), "printers", map[string]interface{}{"Mac": "00:00:00:00:00:02"})
+	}()
+
+	// Cancel the first caller while the batch carrying both entries is being sent
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled add returned %v, want context.Canceled", err)
+	}
+	close(f.release)
+
+	if err := <-other; err != nil {
+		t.Errorf("the other add failed: %v", err)
+	}
+	if got := fmt.Sprint(f.sizes()); got != "[2]" {
+		t.Errorf("batches = %s, want one batch of 2", got)
+	}
+	if f.ctxErrs[0] != nil {
+		t.Errorf("the batch was sent with a cancelled context: %v", f.ctxErrs[0])
+	}
+}
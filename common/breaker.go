@@ -0,0 +1,104 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultCircuitBreakerCooldown is how long an open circuit fails requests fast before probing the API again
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// CircuitOpenError is returned without calling the API while the circuit breaker is open
+type CircuitOpenError struct {
+	Failures int       // Consecutive failures that tripped the breaker
+	RetryAt  time.Time // When the breaker lets a request through again
+	LastErr  error     // The failure that tripped the breaker
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("the Portnox API failed %d consecutive requests, failing fast until %s (last error: %v)", e.Failures, e.RetryAt.Format(time.RFC3339), e.LastErr)
+}
+
+// circuitBreaker trips after a number of consecutive server or connection failures shared by all resources,
+// so an API outage fails the remaining operations fast instead of each one spending its full retry budget.
+// After the cooldown a single request is let through; its success closes the circuit again.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	lastErr   error
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow returns a CircuitOpenError when requests should not be sent to the API
+func (b *circuitBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	// Let one probe through once the cooldown has passed
+	if time.Now().After(b.openUntil) && !b.probing {
+		b.probing = true
+		return nil
+	}
+	return &CircuitOpenError{Failures: b.failures, RetryAt: b.openUntil, LastErr: b.lastErr}
+}
+
+// record updates the breaker with the outcome of a request. Only server errors and connection failures count
+// as failures; client errors show the API is up.
+func (b *circuitBreaker) record(err error) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	var apiErr *APIError
+	if err == nil || (errors.As(err, &apiErr) && apiErr.StatusCode < 500) {
+		b.failures = 0
+		b.lastErr = nil
+		return
+	}
+
+	b.failures++
+	b.lastErr = err
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// release lets another probe through after a request whose outcome says nothing about the API, such as one that was
+// cancelled, without counting it as a success or a failure
+func (b *circuitBreaker) release() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
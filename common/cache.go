@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultRequestCacheTTL bounds how long a cached GET response is reused within a single Terraform operation
+const defaultRequestCacheTTL = 60 * time.Second
+
+type cacheEntry struct {
+	body       []byte
+	err        error
+	expires    time.Time
+	generation uint64        // The generation of the cache the request was started in
+	done       chan struct{} // Closed once the request that fills the entry has completed
+}
+
+// requestCache stores GET responses by endpoint and collapses concurrent identical requests into one call
+type requestCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	entries    map[string]*cacheEntry
+	generation uint64 // Incremented by clear; entries of earlier generations, even in flight, are never returned
+}
+
+func newRequestCache(ttl time.Duration) *requestCache {
+	if ttl <= 0 {
+		ttl = defaultRequestCacheTTL
+	}
+	return &requestCache{
+		ttl:     ttl,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// get returns the cached response for key, calling fetch at most once for concurrent callers.
+// Callers waiting on a failed request receive the same error, but the failure is not kept for later callers.
+// A request in flight when the cache is cleared may have read data from before the change, so its callers fetch
+// again rather than receive its response.
+// The request is shared by all callers, so fetch must not be tied to the context of one of them; each caller stops
+// waiting when its own ctx is cancelled, without cancelling the request for the others.
+func (rc *requestCache) get(ctx context.Context, key string, fetch func() ([]byte, error)) ([]byte, error) {
+	for {
+		rc.mu.Lock()
+		entry, ok := rc.entries[key]
+		if !ok || entry.generation != rc.generation {
+			entry = &cacheEntry{generation: rc.generation, done: make(chan struct{})}
+			rc.entries[key] = entry
+			go rc.fill(key, entry, fetch)
+		}
+		rc.mu.Unlock()
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		rc.mu.Lock()
+		current := entry.generation == rc.generation
+		rc.mu.Unlock()
+		if !current {
+			continue
+		}
+		if entry.err != nil || time.Now().Before(entry.expires) {
+			return entry.body, entry.err
+		}
+
+		rc.mu.Lock()
+		if rc.entries[key] == entry {
+			delete(rc.entries, key)
+		}
+		rc.mu.Unlock()
+	}
+}
+
+// fill makes the request of an entry, and drops the entry when the request failed
+func (rc *requestCache) fill(key string, entry *cacheEntry, fetch func() ([]byte, error)) {
+	entry.body, entry.err = fetch()
+	entry.expires = time.Now().Add(rc.ttl)
+	close(entry.done)
+
+	if entry.err != nil {
+		rc.mu.Lock()
+		if rc.entries[key] == entry {
+			delete(rc.entries, key)
+		}
+		rc.mu.Unlock()
+	}
+}
+
+// clear invalidates all cached responses and the requests in flight, used after any request that may have changed
+// server-side state
+func (rc *requestCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generation++
+	rc.entries = make(map[string]*cacheEntry)
+}
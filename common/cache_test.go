@@ -0,0 +1,159 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestCacheSingleflight(t *testing.T) {
+	rc := newRequestCache(time.Minute)
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	fetch := func() ([]byte, error) {
+		fetches.Add(1)
+		<-release
+		return []byte("body"), nil
+	}
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 10)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body, err := rc.get(context.Background(), "GET /api/vlans", fetch)
+			if err != nil {
+				t.Errorf("get %d: %v", i, err)
+			}
+			bodies[i] = string(body)
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("fetched %d times, want once for concurrent callers", got)
+	}
+	for i, body := range bodies {
+		if body != "body" {
+			t.Errorf("get %d returned %q", i, body)
+		}
+	}
+
+	// Later callers get the cached response
+	if _, err := rc.get(context.Background(), "GET /api/vlans", fetch); err != nil {
+		t.Fatal(err)
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("fetched %d times, want the cached response to be reused", got)
+	}
+}
+
+func TestRequestCacheCallerCancellation(t *testing.T) {
+	rc := newRequestCache(time.Minute)
+	release := make(chan struct{})
+	fetch := func() ([]byte, error) {
+		<-release
+		return []byte("body"), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := rc.get(ctx, "GET /api/vlans", fetch)
+		cancelled <- err
+	}()
+	other := make(chan error, 1)
+	go func() {
+		_, err := rc.get(context.Background(), "GET /api/vlans", fetch)
+		other <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled get returned %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-other; err != nil {
+		t.Errorf("the other get failed: %v", err)
+	}
+}
+
+func TestRequestCacheClear(t *testing.T) {
+	rc := newRequestCache(time.Minute)
+	var fetches atomic.Int32
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	fetch := func() ([]byte, error) {
+		n := fetches.Add(1)
+		started <- struct{}{}
+		<-release
+		return []byte(fmt.Sprintf("revision %d", n)), nil
+	}
+
+	result := make(chan string, 1)
+	go func() {
+		body, err := rc.get(context.Background(), "GET /api/vlans", fetch)
+		if err != nil {
+			t.Error(err)
+		}
+		result <- string(body)
+	}()
+
+	// A mutation completes while the first request is in flight, so its response may predate the change
+	<-started
+	rc.clear()
+	close(release)
+
+	if body := <-result; body != "revision 2" {
+		t.Errorf("get returned %q, want the response of a request made after the clear", body)
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Errorf("fetched %d times, want 2", got)
+	}
+}
+
+func TestMakeRequestWithRetryClearsCache(t *testing.T) {
+	var gets atomic.Int32
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			gets.Add(1)
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer httpServer.Close()
+
+	config := &Config{BaseURL: httpServer.URL, Retries: 1}
+	ctx := context.Background()
+	read := func() {
+		if _, err := config.MakeCachedRequestWithRetry(ctx, "/api/mac-based-accounts/printers"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read()
+	if _, err := config.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts/search", map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+	read()
+	if got := gets.Load(); got != 1 {
+		t.Errorf("sent %d GET requests, want a search to keep the cached response", got)
+	}
+
+	if _, err := config.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts/mac-whitelist-add", map[string]interface{}{"AccountName": "printers"}); err != nil {
+		t.Fatal(err)
+	}
+	read()
+	if got := gets.Load(); got != 2 {
+		t.Errorf("sent %d GET requests, want a mutation to clear the cache", got)
+	}
+}
@@ -0,0 +1,124 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// WhitelistFormat is the shape in which the API returns the MAC whitelist of an account
+type WhitelistFormat int
+
+const (
+	// WhitelistFormatUnknown means the format was not detected, and each response is inspected for either shape
+	WhitelistFormatUnknown WhitelistFormat = iota
+	// WhitelistFormatArray is AgentlessOptions.MacWhiteList as an array of entries
+	WhitelistFormatArray
+	// WhitelistFormatItems is AgentlessOptions.MacWhiteList as an object with an _items array, used before API version 2
+	WhitelistFormatItems
+)
+
+func (f WhitelistFormat) String() string {
+	switch f {
+	case WhitelistFormatArray:
+		return "array"
+	case WhitelistFormatItems:
+		return "items"
+	default:
+		return "unknown"
+	}
+}
+
+// Capabilities describes the API version of the tenant and the features it supports, detected once when the
+// provider is configured so resources can branch on the response shape deliberately
+type Capabilities struct {
+	APIVersion      string
+	Features        map[string]bool
+	WhitelistFormat WhitelistFormat
+}
+
+// HasFeature reports whether the tenant advertises a feature flag
+func (c *Capabilities) HasFeature(name string) bool {
+	if c == nil {
+		return false
+	}
+	return c.Features[name]
+}
+
+// DetectCapabilities reads the API version and feature flags of the tenant into Capabilities. Tenants that do not
+// expose the version endpoint keep unknown capabilities, which is not an error: responses are then inspected instead.
+func (c *Config) DetectCapabilities(ctx context.Context) error {
+	responseBody, err := c.MakeRequestWithRetry(ctx, "GET", "/api/version", nil)
+	if err != nil {
+		if c.IsNotFoundError(err) {
+			log.Printf("[DEBUG] API version endpoint not available, response formats will be detected per response")
+			c.Capabilities = &Capabilities{Features: map[string]bool{}}
+			return nil
+		}
+		return err
+	}
+
+	capabilities, err := parseCapabilities(responseBody)
+	if err != nil {
+		return err
+	}
+	c.Capabilities = capabilities
+
+	log.Printf("[INFO] Detected Portnox API version %q with MAC whitelist format %s", capabilities.APIVersion, capabilities.WhitelistFormat)
+	return nil
+}
+
+// parseCapabilities reads a version response. The whitelist format is taken from MacWhiteListFormat when the API
+// reports it, and otherwise derived from the major API version.
+func parseCapabilities(responseBody []byte) (*Capabilities, error) {
+	var response struct {
+		ApiVersion         string   `json:"ApiVersion"`
+		Features           []string `json:"Features"`
+		MacWhiteListFormat string   `json:"MacWhiteListFormat"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, err
+	}
+
+	capabilities := &Capabilities{
+		APIVersion: response.ApiVersion,
+		Features:   make(map[string]bool, len(response.Features)),
+	}
+	for _, feature := range response.Features {
+		capabilities.Features[feature] = true
+	}
+
+	switch strings.ToLower(response.MacWhiteListFormat) {
+	case "array":
+		capabilities.WhitelistFormat = WhitelistFormatArray
+	case "items":
+		capabilities.WhitelistFormat = WhitelistFormatItems
+	default:
+		major, _, _ := strings.Cut(strings.TrimPrefix(response.ApiVersion, "v"), ".")
+		if version, err := strconv.Atoi(major); err == nil {
+			if version < 2 {
+				capabilities.WhitelistFormat = WhitelistFormatItems
+			} else {
+				capabilities.WhitelistFormat = WhitelistFormatArray
+			}
+		}
+	}
+
+	return capabilities, nil
+}
+
+// WhitelistFormat returns the detected whitelist format, or WhitelistFormatUnknown when capabilities were not detected
+func (c *Config) WhitelistFormat() WhitelistFormat {
+	if c.Capabilities == nil {
+		return WhitelistFormatUnknown
+	}
+	return c.Capabilities.WhitelistFormat
+}
+
+// WhitelistUpsert reports whether the tenant advertises the MacWhiteListUpsert feature, with which whitelist-add
+// replaces the attributes of a MAC address that is already whitelisted instead of rejecting it
+func (c *Config) WhitelistUpsert() bool {
+	return c.Capabilities.HasFeature("MacWhiteListUpsert")
+}
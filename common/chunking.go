@@ -0,0 +1,120 @@
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// defaultWhitelistChunkSize caps the number of entries sent in one whitelist add or remove request, so whitelists
+// with tens of thousands of MAC addresses stay under the payload limits of the API gateway
+const defaultWhitelistChunkSize = 1000
+
+// ifMatchHeader is the request header carrying the revision a mutation expects the object to be at
+const ifMatchHeader = "If-Match"
+
+// compressThreshold is the request body size from which bodies are gzip-compressed when CompressRequests is set;
+// smaller bodies gain nothing from compression
+const compressThreshold = 8 * 1024
+
+// whitelistChunks splits the MacWhiteList of a whitelist add or remove payload into payloads of at most chunkSize
+// entries. It returns nil when the request is not a whitelist change or already fits in one request.
+func whitelistChunks(endpoint string, payload interface{}, chunkSize int) []map[string]interface{} {
+	path, _, _ := strings.Cut(endpoint, "?")
+	if !strings.HasSuffix(path, "/mac-whitelist-add") && !strings.HasSuffix(path, "/mac-whitelist-remove") {
+		return nil
+	}
+	payloadMap, ok := payload.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	entries, ok := payloadMap["MacWhiteList"].([]map[string]interface{})
+	if !ok || len(entries) <= chunkSize {
+		return nil
+	}
+
+	chunks := make([]map[string]interface{}, 0, (len(entries)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(entries); start += chunkSize {
+		end := min(start+chunkSize, len(entries))
+		chunk := make(map[string]interface{}, len(payloadMap))
+		for key, value := range payloadMap {
+			chunk[key] = value
+		}
+		chunk["MacWhiteList"] = entries[start:end]
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// makeChunkedRequest sends the chunks of a whitelist change one after the other and merges the per-item Results
+// of their responses, so callers see the same response as for a single request. Each chunk is a separate
+// operation with its own idempotency key. A failed chunk stops the change; the chunks before it stay applied.
+//
+// An If-Match header only guards the first chunk against concurrent changes: each chunk changes the revision of the
+// account, so the later chunks are sent with the ETag returned by the chunk before them, or without If-Match when
+// the API did not return one.
+func (c *Config) makeChunkedRequest(ctx context.Context, method, endpoint string, chunks []map[string]interface{}, headers map[string]string) ([]byte, http.Header, error) {
+	var results []json.RawMessage
+	var responseBody []byte
+	var responseHeaders http.Header
+	applied := 0
+	ifMatch := headers[ifMatchHeader]
+
+	for i, chunk := range chunks {
+		chunkHeaders := make(map[string]string, len(headers))
+		for name, value := range headers {
+			if name != ifMatchHeader {
+				chunkHeaders[name] = value
+			}
+		}
+		if ifMatch != "" {
+			chunkHeaders[ifMatchHeader] = ifMatch
+		}
+		if key := headers[IdempotencyKeyHeader]; key != "" {
+			chunkHeaders[IdempotencyKeyHeader] = fmt.Sprintf("%s-%d", key, i+1)
+		}
+
+		var err error
+		responseBody, responseHeaders, err = c.MakeRequestWithRetryAndHeaders(ctx, method, endpoint, chunk, chunkHeaders)
+		if err != nil {
+			return responseBody, responseHeaders, fmt.Errorf("whitelist change chunk %d of %d failed, the %d entries before it were applied: %w", i+1, len(chunks), applied, err)
+		}
+		applied += len(chunk["MacWhiteList"].([]map[string]interface{}))
+		if ifMatch != "" {
+			ifMatch = responseHeaders.Get("ETag")
+		}
+
+		var response struct {
+			Results []json.RawMessage `json:"Results"`
+		}
+		if json.Unmarshal(responseBody, &response) == nil {
+			results = append(results, response.Results...)
+		}
+	}
+
+	if len(results) == 0 {
+		return responseBody, responseHeaders, nil
+	}
+	merged, err := json.Marshal(map[string]interface{}{"Results": results})
+	if err != nil {
+		return nil, responseHeaders, err
+	}
+	return merged, responseHeaders, nil
+}
+
+// compressBody gzip-compresses a request body
+func compressBody(body []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
@@ -0,0 +1,124 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// etagServer is a whitelist API that rejects a change whose If-Match is not the current revision of the account,
+// and returns the new revision as ETag after every change when returnETag is set
+type etagServer struct {
+	mu         sync.Mutex
+	revision   int
+	returnETag bool
+	added      int
+	ifMatches  []string
+}
+
+func (s *etagServer) etag() string {
+	return fmt.Sprintf(`"rev-%d"`, s.revision)
+}
+
+func (s *etagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ifMatch := r.Header.Get("If-Match")
+	s.ifMatches = append(s.ifMatches, ifMatch)
+	if ifMatch != "" && ifMatch != s.etag() {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		fmt.Fprint(w, `{"InternalError":"the account was modified"}`)
+		return
+	}
+
+	var payload struct {
+		MacWhiteList []map[string]interface{} `json:"MacWhiteList"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.added += len(payload.MacWhiteList)
+	s.revision++
+	if s.returnETag {
+		w.Header().Set("ETag", s.etag())
+	}
+	fmt.Fprint(w, `{}`)
+}
+
+func whitelistPayload(count int) map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		entries = append(entries, map[string]interface{}{"Mac": fmt.Sprintf("00:00:00:00:%02X:%02X", i/256, i%256)})
+	}
+	return map[string]interface{}{"AccountName": "printers", "MacWhiteList": entries}
+}
+
+func TestMakeChunkedRequestIfMatch(t *testing.T) {
+	cases := []struct {
+		name          string
+		returnETag    bool
+		ifMatch       string
+		wantErr       bool
+		wantAdded     int
+		wantIfMatches []string
+	}{
+		{
+			name:          "each chunk is sent with the revision of the chunk before it",
+			returnETag:    true,
+			ifMatch:       `"rev-0"`,
+			wantAdded:     25,
+			wantIfMatches: []string{`"rev-0"`, `"rev-1"`, `"rev-2"`},
+		},
+		{
+			name:          "a stale revision fails the first chunk",
+			returnETag:    true,
+			ifMatch:       `"rev-7"`,
+			wantErr:       true,
+			wantAdded:     0,
+			wantIfMatches: []string{`"rev-7"`},
+		},
+		{
+			name:          "later chunks are sent without If-Match when no ETag is returned",
+			ifMatch:       `"rev-0"`,
+			wantAdded:     25,
+			wantIfMatches: []string{`"rev-0"`, "", ""},
+		},
+		{
+			name:          "no If-Match",
+			returnETag:    true,
+			wantAdded:     25,
+			wantIfMatches: []string{"", "", ""},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &etagServer{returnETag: tc.returnETag}
+			httpServer := httptest.NewServer(server)
+			defer httpServer.Close()
+
+			config := &Config{BaseURL: httpServer.URL, Retries: 1, WhitelistChunkSize: 10}
+			headers := map[string]string{}
+			if tc.ifMatch != "" {
+				headers["If-Match"] = tc.ifMatch
+			}
+
+			_, _, err := config.MakeRequestWithRetryAndHeaders(context.Background(), "POST", "/api/mac-based-accounts/mac-whitelist-add", whitelistPayload(25), headers)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tc.wantErr)
+			}
+			if server.added != tc.wantAdded {
+				t.Errorf("added %d entries, want %d", server.added, tc.wantAdded)
+			}
+			if fmt.Sprint(server.ifMatches) != fmt.Sprint(tc.wantIfMatches) {
+				t.Errorf("If-Match headers = %q, want %q", server.ifMatches, tc.wantIfMatches)
+			}
+		})
+	}
+}
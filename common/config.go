@@ -2,23 +2,74 @@ package common
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
-	"math/rand"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Config struct {
 	APIKey        string
 	BaseURL       string
 	Logger        *log.Logger
-	Retries       int // Number of retries for API requests
-	RetryInterval int // Retry interval in seconds between retries
+	Retries       int               // Number of retries for API requests
+	RetryInterval int               // Retry interval in seconds between retries
+	Transport     http.RoundTripper // Optional transport override, e.g. a VCRTransport for recording or replaying API traffic
+	UserAgent     string            // User-Agent sent with every request, identifying the provider and Terraform versions
+	DefaultTags   map[string]string // Tags merged into the tags of every resource that supports them
+
+	EndpointOverrides map[string]string // API path prefixes replaced by the provider endpoints block, keyed by the default prefix
+	Capabilities      *Capabilities     // API version and features of the tenant, nil until DetectCapabilities has run
+
+	DisableRequestBodyLogging bool   // Omit request and response bodies from debug logs entirely
+	AuditLogPath              string // File that receives a JSONL audit record for every API mutation
+	LogStatistics             bool   // Log a summary of the API call statistics after every resource and data source operation
+	StatisticsPath            string // File rewritten with a JSON summary of the API call statistics after every operation
+
+	DisableRequestCache bool          // Disable caching of GET responses made through MakeCachedRequestWithRetry
+	RequestCacheTTL     time.Duration // How long cached GET responses are reused, defaults to 60 seconds
+
+	WhitelistBatchWindow time.Duration // Window in which whitelist adds for the same account are coalesced, 0 disables batching
+	WhitelistChunkSize   int           // Maximum entries per whitelist add or remove request, 0 uses the default of 1000
+	CompressRequests     bool          // Gzip-compress large request bodies
+	VerifyWrites         bool          // Re-read whitelists after writes and re-send the changes the API did not apply
+	ValidateOnly         bool          // Send mutations to the API for validation only, returning a ValidateOnlyError instead of applying them
+
+	MaxRetryElapsedTime time.Duration // Wall-clock budget after the first request beyond which no request is retried, 0 is unlimited
+	BackoffStrategy     string        // How the wait between retries grows, one of BackoffStrategies; empty uses exponential_jitter
+	MaxBackoff          time.Duration // Longest wait between two retries, 0 is unlimited
+
+	ReadRetries        int // Retries for reads (GET and search requests), 0 uses Retries
+	ReadRetryInterval  int // Retry interval in seconds for reads, 0 uses RetryInterval
+	WriteRetries       int // Retries for mutations, 0 uses Retries
+	WriteRetryInterval int // Retry interval in seconds for mutations, 0 uses RetryInterval
+
+	CircuitBreakerThreshold int           // Consecutive API failures after which requests fail fast, 0 disables the breaker
+	CircuitBreakerCooldown  time.Duration // How long requests fail fast before the API is probed again, defaults to 30 seconds
+
+	cacheOnce   sync.Once
+	cache       *requestCache
+	batcherOnce sync.Once
+	batcher     *whitelistBatcher
+	breakerOnce sync.Once
+	breaker     *circuitBreaker
+
+	retryDeadlineOnce sync.Once
+	retryDeadline     time.Time
+
+	auditMu sync.Mutex
+
+	statisticsMu sync.Mutex
+	statistics   *apiStatistics
 }
 
 func NewConfig(apiKey string, baseURL string, retries int, retryInterval int, logger *log.Logger) *Config {
@@ -35,24 +86,33 @@ func NewConfig(apiKey string, baseURL string, retries int, retryInterval int, lo
 	}
 }
 
-func (c *Config) MakeRequest(method, endpoint string, payload interface{}) ([]byte, error) {
-	url := c.BaseURL + endpoint
+func (c *Config) MakeRequest(ctx context.Context, method, endpoint string, payload interface{}) ([]byte, error) {
+	responseBody, _, err := c.MakeRequestWithHeaders(ctx, method, endpoint, payload, nil)
+	return responseBody, err
+}
+
+// MakeRequestWithHeaders performs a single API request with additional request headers, such as If-Match,
+// and returns the response headers alongside the body. The request is aborted when ctx is cancelled.
+func (c *Config) MakeRequestWithHeaders(ctx context.Context, method, endpoint string, payload interface{}, headers map[string]string) ([]byte, http.Header, error) {
+	url := c.BaseURL + c.resolveEndpoint(endpoint)
 
 	body, err := json.Marshal(payload)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	maskedAPIKey := c.APIKey[:1] + "*************************" + c.APIKey[len(c.APIKey)-1:]
+	requestHeaders := http.Header{}
+	requestHeaders.Set("Authorization", "Bearer "+c.APIKey)
+	requestHeaders.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		requestHeaders.Set(name, value)
+	}
 
 	requestLog := map[string]interface{}{
-		"method": method,
-		"url":    url,
-		"headers": map[string]string{
-			"Authorization": "Bearer " + maskedAPIKey,
-			"Content-Type":  "application/json",
-		},
-		"body": string(body),
+		"method":  method,
+		"url":     url,
+		"headers": redactHeaders(requestHeaders, c.APIKey),
+		"body":    c.loggableBody(body),
 	}
 
 	if logJSON, err := json.MarshalIndent(requestLog, "", "  "); err == nil {
@@ -63,35 +123,70 @@ func (c *Config) MakeRequest(method, endpoint string, payload interface{}) ([]by
 		}
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	// Responses are compressed transparently: the transport asks for gzip and decompresses the response
+	requestBody := body
+	if c.CompressRequests && len(body) >= compressThreshold {
+		if requestBody, err = compressBody(body); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(requestBody))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if len(requestBody) != len(body) {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	// Count the request in the API statistics and end its trace span once the response is read
+	spanCtx, span := StartSpan(ctx, "HTTP "+method,
+		attribute.String("http.request.method", method),
+		attribute.String("url.path", c.resolveEndpoint(endpoint)),
+	)
+	start := time.Now()
+	finish := func(statusCode int, err error) {
+		c.recordRequest(ctx, time.Since(start), statusCode, err)
+		if statusCode != 0 {
+			span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+		}
+		EndSpan(span, err)
+	}
+
+	client := &http.Client{Transport: c.Transport}
+	resp, err := client.Do(req.WithContext(spanCtx))
 	if err != nil {
+		finish(0, err)
+		c.audit(ctx, method, endpoint, headers[IdempotencyKeyHeader], body, 0, err)
 		if c.Logger != nil {
 			c.Logger.Printf("[ERROR] HTTP request failed: %v", err)
 		} else {
 			log.Printf("[ERROR] HTTP request failed: %v", err)
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		finish(resp.StatusCode, err)
+		c.audit(ctx, method, endpoint, headers[IdempotencyKeyHeader], body, resp.StatusCode, err)
+		return nil, resp.Header, err
 	}
 
 	responseLog := map[string]interface{}{
 		"status":  resp.Status,
-		"headers": resp.Header,
-		"body":    string(responseBody),
+		"headers": redactHeaders(resp.Header, c.APIKey),
+		"body":    c.loggableBody(responseBody),
 	}
 
 	if logJSON, err := json.MarshalIndent(responseLog, "", "  "); err == nil {
@@ -103,76 +198,245 @@ func (c *Config) MakeRequest(method, endpoint string, payload interface{}) ([]by
 	}
 
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API request failed with status: %s", resp.Status)
+		apiErr := newAPIError(resp, responseBody)
+		finish(resp.StatusCode, apiErr)
+		c.audit(ctx, method, endpoint, headers[IdempotencyKeyHeader], body, resp.StatusCode, apiErr)
+		return responseBody, resp.Header, apiErr
 	}
 
-	return responseBody, nil
+	finish(resp.StatusCode, nil)
+	c.audit(ctx, method, endpoint, headers[IdempotencyKeyHeader], body, resp.StatusCode, nil)
+	return responseBody, resp.Header, nil
+}
+
+// loggableBody returns a request or response body with secrets redacted, or a placeholder when body
+// logging is disabled
+func (c *Config) loggableBody(body []byte) string {
+	if c.DisableRequestBodyLogging {
+		return "(omitted, disable_request_body_logging is set)"
+	}
+	return RedactSecrets(string(body), c.APIKey)
 }
 
-// IsNotFoundError checks if an error corresponds to a 404 Not Found response
+// IsNotFoundError checks if an error corresponds to a 404 Not Found response or the
+// 400 response with InternalErrorCode 5357 that the API returns for missing accounts
 func (c *Config) IsNotFoundError(err error) bool {
-	if err == nil {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsNotFound()
+	}
+	return false
+}
+
+// MakeCachedRequestWithRetry performs an idempotent GET through a short-lived response cache, so identical
+// lookups made during one Terraform operation (e.g. many data sources reading the same account) hit the API once
+func (c *Config) MakeCachedRequestWithRetry(ctx context.Context, endpoint string) ([]byte, error) {
+	if c.DisableRequestCache {
+		return c.MakeRequestWithRetry(ctx, "GET", endpoint, nil)
+	}
+
+	// The request is shared with concurrent callers, so it is made without the cancellation of this one
+	fetchCtx := context.WithoutCancel(ctx)
+	return c.requestCache().get(ctx, "GET "+endpoint, func() ([]byte, error) {
+		return c.MakeRequestWithRetry(fetchCtx, "GET", endpoint, nil)
+	})
+}
+
+// AddToWhitelist adds one entry to the MAC whitelist of an account. When WhitelistBatchWindow is set, adds
+// for the same account made within the window are sent together in a single whitelist-add request.
+// If the API rejects only some entries of a batch, only the callers of those entries get a WhitelistItemError.
+func (c *Config) AddToWhitelist(ctx context.Context, accountName string, entry map[string]interface{}) error {
+	flush := func(ctx context.Context, accountName string, entries []map[string]interface{}) ([]byte, error) {
+		payload := map[string]interface{}{
+			"AccountName":  accountName,
+			"MacWhiteList": entries,
+		}
+		return c.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts/mac-whitelist-add", payload)
+	}
+
+	if c.WhitelistBatchWindow <= 0 {
+		responseBody, err := flush(ctx, accountName, []map[string]interface{}{entry})
+		return whitelistAddResult(entry, responseBody, err)
+	}
+
+	c.batcherOnce.Do(func() {
+		c.batcher = newWhitelistBatcher(c.WhitelistBatchWindow, flush)
+	})
+
+	return c.batcher.add(ctx, accountName, entry)
+}
+
+func (c *Config) requestCache() *requestCache {
+	c.cacheOnce.Do(func() {
+		c.cache = newRequestCache(c.RequestCacheTTL)
+	})
+	return c.cache
+}
+
+func (c *Config) circuitBreaker() *circuitBreaker {
+	c.breakerOnce.Do(func() {
+		c.breaker = newCircuitBreaker(c.CircuitBreakerThreshold, c.CircuitBreakerCooldown)
+	})
+	return c.breaker
+}
+
+// retryBudgetExhausted reports whether waiting the given backoff would exceed MaxRetryElapsedTime, measured
+// from the first request made by the provider so retries across all resources share one budget
+func (c *Config) retryBudgetExhausted(wait time.Duration) bool {
+	if c.MaxRetryElapsedTime <= 0 {
 		return false
 	}
+	c.retryDeadlineOnce.Do(func() {
+		c.retryDeadline = time.Now().Add(c.MaxRetryElapsedTime)
+	})
+	return time.Now().Add(wait).After(c.retryDeadline)
+}
 
-	// Check for 404 status in the error message
-	if strings.Contains(err.Error(), "404") {
+// isReadRequest reports whether a request only reads data: a GET, or a POST to a search endpoint
+func isReadRequest(method, endpoint string) bool {
+	if method == "GET" || method == "HEAD" {
 		return true
 	}
+	path, _, _ := strings.Cut(endpoint, "?")
+	return method == "POST" && strings.HasSuffix(path, "/search")
+}
 
-	// Check for specific 400 error with InternalErrorCode 5357
-	if strings.Contains(err.Error(), "400") {
-		var errorResponse struct {
-			InternalErrorCode int `json:"InternalErrorCode"`
-		}
-		if jsonErr := json.Unmarshal([]byte(err.Error()), &errorResponse); jsonErr == nil {
-			if errorResponse.InternalErrorCode == 5357 {
-				return true
-			}
-		}
+// retrySettings returns the number of attempts and the initial retry interval in seconds for a request,
+// using the read or write overrides when set
+func (c *Config) retrySettings(method, endpoint string) (int, int) {
+	retries, interval := c.WriteRetries, c.WriteRetryInterval
+	if isReadRequest(method, endpoint) {
+		retries, interval = c.ReadRetries, c.ReadRetryInterval
+	}
+	if retries <= 0 {
+		retries = c.Retries
+	}
+	if interval <= 0 {
+		interval = c.RetryInterval
 	}
+	// retries = 0 disables retrying, but the request itself is always sent
+	if retries < 1 {
+		retries = 1
+	}
+	return retries, interval
+}
 
-	return false
+func (c *Config) MakeRequestWithRetry(ctx context.Context, method, endpoint string, payload interface{}) ([]byte, error) {
+	responseBody, _, err := c.MakeRequestWithRetryAndHeaders(ctx, method, endpoint, payload, nil)
+	return responseBody, err
 }
 
-func (c *Config) MakeRequestWithRetry(method, endpoint string, payload interface{}) ([]byte, error) {
+// MakeRequestWithRetryAndHeaders is MakeRequestWithRetry with additional request headers, returning the
+// response headers of the last attempt. Backoff sleeps end early when ctx is cancelled.
+func (c *Config) MakeRequestWithRetryAndHeaders(ctx context.Context, method, endpoint string, payload interface{}, headers map[string]string) ([]byte, http.Header, error) {
 	var responseBody []byte
+	var responseHeaders http.Header
 	var err error
-	backoff := c.RetryInterval // Initial backoff in seconds, based on RetryInterval
+
+	// Only ask the API to validate mutations when the provider runs in validate_only mode
+	if c.ValidateOnly && !isReadRequest(method, endpoint) && !isValidating(ctx) && !isValidateOnlyExempt(ctx) {
+		return c.validateMutation(ctx, method, endpoint, payload, headers)
+	}
+
+	// Split very large whitelist changes into requests that stay under the payload limits of the API gateway
+	chunkSize := c.WhitelistChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultWhitelistChunkSize
+	}
+	if chunks := whitelistChunks(endpoint, payload, chunkSize); chunks != nil {
+		return c.makeChunkedRequest(ctx, method, endpoint, chunks, headers)
+	}
+
+	// Any mutation may change what a cached GET would return, while searches leave the cache in place
+	if !isReadRequest(method, endpoint) {
+		c.requestCache().clear()
+	}
+
+	// Send every attempt of a mutation with the same idempotency key, so a retry cannot apply it twice
+	if !isReadRequest(method, endpoint) && headers[IdempotencyKeyHeader] == "" {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		withKey := make(map[string]string, len(headers)+1)
+		for name, value := range headers {
+			withKey[name] = value
+		}
+		withKey[IdempotencyKeyHeader] = key
+		headers = withKey
+	}
+	maxRetries, retryInterval := c.retrySettings(method, endpoint)
+	var wait time.Duration // The previous wait, which the decorrelated strategy grows from
+
+	// Start the shared retry budget with the first request
+	c.retryBudgetExhausted(0)
 
 	if c.Logger != nil {
-		c.Logger.Printf("[DEBUG] Starting MakeRequestWithRetry with maxRetries=%d and retry_interval=%d", c.Retries, c.RetryInterval)
+		c.Logger.Printf("[DEBUG] Starting MakeRequestWithRetry with maxRetries=%d and retry_interval=%d", maxRetries, retryInterval)
 	} else {
-		log.Printf("[DEBUG] Starting MakeRequestWithRetry with maxRetries=%d and retry_interval=%d", c.Retries, c.RetryInterval)
+		log.Printf("[DEBUG] Starting MakeRequestWithRetry with maxRetries=%d and retry_interval=%d", maxRetries, retryInterval)
 	}
 
-	for attempt := 1; attempt <= c.Retries; attempt++ {
+	for attempt := 1; attempt <= maxRetries; attempt++ {
 		if c.Logger != nil {
-			c.Logger.Printf("[DEBUG] Attempt %d/%d: Making request to %s", attempt, c.Retries, endpoint)
+			c.Logger.Printf("[DEBUG] Attempt %d/%d: Making request to %s", attempt, maxRetries, endpoint)
 		} else {
-			log.Printf("[DEBUG] Attempt %d/%d: Making request to %s", attempt, c.Retries, endpoint)
+			log.Printf("[DEBUG] Attempt %d/%d: Making request to %s", attempt, maxRetries, endpoint)
+		}
+
+		// Fail fast while the API is known to be down
+		if err := c.circuitBreaker().allow(); err != nil {
+			if c.Logger != nil {
+				c.Logger.Printf("[ERROR] Circuit breaker open, not sending request to %s: %v", endpoint, err)
+			} else {
+				log.Printf("[ERROR] Circuit breaker open, not sending request to %s: %v", endpoint, err)
+			}
+			return nil, nil, err
 		}
 
-		responseBody, err = c.MakeRequest(method, endpoint, payload)
+		responseBody, responseHeaders, err = c.MakeRequestWithHeaders(ctx, method, endpoint, payload, headers)
+
+		// A cancelled request says nothing about the health of the API
+		if ctx.Err() != nil {
+			c.circuitBreaker().release()
+			return responseBody, responseHeaders, ctx.Err()
+		}
+		c.circuitBreaker().record(err)
 		if err == nil {
 			if c.Logger != nil {
 				c.Logger.Printf("[DEBUG] Request succeeded on attempt %d", attempt)
 			} else {
 				log.Printf("[DEBUG] Request succeeded on attempt %d", attempt)
 			}
-			return responseBody, nil
+			return responseBody, responseHeaders, nil
 		}
 
-		// Check if the error is a 429 Too Many Requests
-		if strings.Contains(err.Error(), "429") {
-			jitter := time.Duration(rand.Intn(1000)) * time.Millisecond // Add random jitter up to 1 second
+		// Retry rate limiting, server errors, and connection failures
+		if isRetryableError(err) {
+			wait = c.backoffWait(time.Duration(retryInterval)*time.Second, attempt-1, wait)
+			if attempt < maxRetries && c.retryBudgetExhausted(wait) {
+				if c.Logger != nil {
+					c.Logger.Printf("[ERROR] Not retrying, max_retry_elapsed_time of %s would be exceeded", c.MaxRetryElapsedTime)
+				} else {
+					log.Printf("[ERROR] Not retrying, max_retry_elapsed_time of %s would be exceeded", c.MaxRetryElapsedTime)
+				}
+				return responseBody, responseHeaders, fmt.Errorf("retry budget of %s (max_retry_elapsed_time) exhausted: %w", c.MaxRetryElapsedTime, err)
+			}
 			if c.Logger != nil {
-				c.Logger.Printf("[WARN] Received 429 Too Many Requests. Retrying in %d seconds with jitter (attempt %d/%d)...", backoff, attempt, c.Retries)
+				c.Logger.Printf("[WARN] Retryable error: %v. Retrying in %s (attempt %d/%d)...", err, wait.Round(time.Millisecond), attempt, maxRetries)
 			} else {
-				log.Printf("[WARN] Received 429 Too Many Requests. Retrying in %d seconds with jitter (attempt %d/%d)...", backoff, attempt, c.Retries)
+				log.Printf("[WARN] Retryable error: %v. Retrying in %s (attempt %d/%d)...", err, wait.Round(time.Millisecond), attempt, maxRetries)
+			}
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return responseBody, responseHeaders, ctx.Err()
+			case <-timer.C:
+			}
+			if attempt < maxRetries {
+				c.recordRetry(ctx, wait)
 			}
-			time.Sleep(time.Duration(backoff)*time.Second + jitter)
-			backoff *= 2 // Exponential backoff
 			continue
 		}
 
@@ -191,5 +455,5 @@ func (c *Config) MakeRequestWithRetry(method, endpoint string, payload interface
 		log.Printf("[ERROR] All retry attempts failed. Returning last error: %v", err)
 	}
 
-	return responseBody, err
+	return responseBody, responseHeaders, err
 }
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"sort"
+	"strings"
+)
+
+// EndpointBases maps the attributes of the provider endpoints block to the API path prefix each one replaces.
+// Portnox occasionally moves endpoints between API versions, and overriding a prefix lets users follow the move
+// without waiting for a provider release.
+var EndpointBases = map[string]string{
+	"access_schedules_base":       "/api/access-schedules",
+	"agent_configurations_base":   "/api/agent-configurations",
+	"branding_base":               "/api/branding",
+	"compliance_reports_base":     "/api/compliance-reports",
+	"conditional_access_base":     "/api/conditional-access-rules",
+	"device_profiling_rules_base": "/api/device-profiling-rules",
+	"devices_base":                "/api/devices",
+	"directory_integrations_base": "/api/directory-integrations",
+	"events_base":                 "/api/events",
+	"license_base":                "/api/license",
+	"mab_bypass_exceptions_base":  "/api/mab-bypass-exceptions",
+	"mac_accounts_base":           "/api/mac-based-accounts",
+	"nas_devices_base":            "/api/nas-devices",
+	"network_segments_base":       "/api/network-segments",
+	"notes_base":                  "/api/notes",
+	"notification_settings_base":  "/api/notification-settings",
+	"organization_base":           "/api/organization",
+	"partner_organizations_base":  "/api/partner/organizations",
+	"policies_base":               "/api/policies",
+	"policy_assignments_base":     "/api/policy-assignments",
+	"posture_checks_base":         "/api/posture-checks",
+	"radius_base":                 "/api/radius",
+	"radsec_base":                 "/api/radsec",
+	"risk_scores_base":            "/api/risk-scores",
+	"scim_settings_base":          "/api/scim-settings",
+	"sessions_base":               "/api/sessions",
+	"site_brokers_base":           "/api/site-brokers",
+	"ssids_base":                  "/api/ssids",
+	"tokens_base":                 "/api/tokens",
+	"user_groups_base":            "/api/user-groups",
+	"users_base":                  "/api/users",
+	"vendors_base":                "/api/vendors",
+	"vlans_base":                  "/api/vlans",
+	"ztna_base":                   "/api/ztna",
+}
+
+// resolveEndpoint replaces the prefix of an endpoint with its configured override. Only whole path segments match,
+// so an override of /api/users does not apply to /api/user-groups.
+func (c *Config) resolveEndpoint(endpoint string) string {
+	if len(c.EndpointOverrides) == 0 {
+		return endpoint
+	}
+
+	// Try longer prefixes first so the most specific override wins
+	bases := make([]string, 0, len(c.EndpointOverrides))
+	for base := range c.EndpointOverrides {
+		bases = append(bases, base)
+	}
+	sort.Slice(bases, func(i, j int) bool { return len(bases[i]) > len(bases[j]) })
+
+	for _, base := range bases {
+		if !strings.HasPrefix(endpoint, base) {
+			continue
+		}
+		rest := endpoint[len(base):]
+		if rest == "" || rest[0] == '/' || rest[0] == '?' {
+			return strings.TrimRight(c.EndpointOverrides[base], "/") + rest
+		}
+	}
+	return endpoint
+}
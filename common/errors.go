@@ -0,0 +1,98 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// InternalErrorCodeNotFound is returned by the Portnox API when the requested account does not exist
+const InternalErrorCodeNotFound = 5357
+
+// APIError describes a failed Portnox API request, including the error details from the response body
+type APIError struct {
+	StatusCode        int
+	Status            string
+	InternalErrorCode int
+	InternalError     string
+	Body              []byte // Raw response body returned by the API
+}
+
+// newAPIError builds an APIError from a failed response, parsing the InternalErrorCode and InternalError
+// fields from the body when present
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+	}
+
+	var errorResponse struct {
+		InternalErrorCode int    `json:"InternalErrorCode"`
+		InternalError     string `json:"InternalError"`
+	}
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		apiErr.InternalErrorCode = errorResponse.InternalErrorCode
+		apiErr.InternalError = errorResponse.InternalError
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.InternalErrorCode != 0 || e.InternalError != "" {
+		return fmt.Sprintf("API request failed with status: %s (InternalErrorCode %d: %s)", e.Status, e.InternalErrorCode, e.InternalError)
+	}
+	return fmt.Sprintf("API request failed with status: %s", e.Status)
+}
+
+// IsNotFound reports whether the API indicated that the requested object does not exist
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound || e.InternalErrorCode == InternalErrorCodeNotFound
+}
+
+// IsConflict reports whether the API rejected a conditional request because the object changed since it was read
+func (e *APIError) IsConflict() bool {
+	return e.StatusCode == http.StatusPreconditionFailed || e.StatusCode == http.StatusConflict
+}
+
+// IsValidationError reports whether the API rejected the request payload
+func (e *APIError) IsValidationError() bool {
+	return e.StatusCode == http.StatusBadRequest && e.InternalErrorCode != InternalErrorCodeNotFound
+}
+
+// Hint returns an actionable explanation for known error codes and statuses, or an empty string
+func (e *APIError) Hint() string {
+	switch {
+	case e.InternalErrorCode == InternalErrorCodeNotFound:
+		return "The MAC-based account does not exist in Portnox. Check the account name, or create the account before managing its whitelist."
+	case e.IsConflict():
+		return "The object was modified outside of this Terraform run since it was last read. Run terraform apply -refresh-only to pick up the current state, then plan again."
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return "The API key was rejected. Check that api_key is valid and has permission to manage this resource."
+	case e.StatusCode == http.StatusTooManyRequests:
+		return "The Portnox API rate limit was exceeded. Increase retries or retry_interval in the provider configuration, or reduce parallelism with terraform apply -parallelism."
+	case e.StatusCode == http.StatusRequestEntityTooLarge:
+		return "The request payload is too large for the API gateway. Lower whitelist_chunk_size or set compress_requests = true in the provider configuration."
+	case e.IsValidationError():
+		return "The Portnox API rejected the request. Check the attribute values against the API rules."
+	case e.StatusCode >= 500:
+		return "The Portnox API encountered an internal error. Retry the operation later."
+	}
+	return ""
+}
+
+// isRetryableError reports whether a failed request may succeed when sent again: a rate-limited (429) request, a
+// server error, or a connection failure. Retries of server errors and connection failures are bounded by the circuit
+// breaker, which stops them once the API looks down, and mutations are safe to resend thanks to their idempotency key.
+func isRetryableError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || strings.Contains(err.Error(), "429")
+}
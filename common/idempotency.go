@@ -0,0 +1,21 @@
+package common
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// IdempotencyKeyHeader is the request header carrying the idempotency key of a mutating request. Every attempt of
+// one logical operation carries the same key, so the API can recognize a retried request it already applied.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// newIdempotencyKey returns a random UUID (version 4) used as the idempotency key of one logical operation
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("error generating idempotency key: %s", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
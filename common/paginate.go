@@ -0,0 +1,87 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPageDelay caps how long Paginate waits for the rate limit window to reset between pages
+const maxPageDelay = 60 * time.Second
+
+// PageHandler handles the response body of one page of a list endpoint, numbered from 1. It returns false when
+// there are no more pages, typically because the page held fewer items than requested or enough were collected.
+type PageHandler func(page int, responseBody []byte) (bool, error)
+
+// Paginate walks a paged list endpoint, requesting page 1, 2, ... in the pageParam query parameter until the handler
+// reports the last page. Each page is requested with the usual retries, and when the API reports that the rate
+// limit is used up the next page waits for the limit to reset instead of running into 429 responses.
+func (c *Config) Paginate(ctx context.Context, method, endpoint, pageParam string, handler PageHandler) error {
+	path, rawQuery, _ := strings.Cut(endpoint, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return err
+	}
+
+	var previous []byte
+	for page := 1; ; page++ {
+		query.Set(pageParam, strconv.Itoa(page))
+
+		responseBody, responseHeaders, err := c.MakeRequestWithRetryAndHeaders(ctx, method, path+"?"+query.Encode(), nil, nil)
+		if err != nil {
+			return err
+		}
+
+		// An endpoint that ignores the page parameter returns the first page again, which would never end
+		if page > 1 && bytes.Equal(responseBody, previous) {
+			log.Printf("[WARN] %s returned the same page twice, assuming it does not support paging", path)
+			return nil
+		}
+		previous = responseBody
+
+		more, err := handler(page, responseBody)
+		if err != nil || !more {
+			return err
+		}
+
+		if wait := rateLimitDelay(responseHeaders, time.Now()); wait > 0 {
+			log.Printf("[DEBUG] Rate limit of %s used up, waiting %s before requesting page %d", path, wait, page+1)
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// rateLimitDelay returns how long to wait before the next request when the response reports no remaining requests
+// in X-RateLimit-Remaining. The wait is taken from Retry-After, or from X-RateLimit-Reset as seconds or a Unix time.
+func rateLimitDelay(headers http.Header, now time.Time) time.Duration {
+	if headers == nil || headers.Get("X-RateLimit-Remaining") != "0" {
+		return 0
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(headers.Get("Retry-After")); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if reset, err := strconv.ParseInt(headers.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		// Small values are a number of seconds, large ones the Unix time at which the window resets
+		if reset > now.Unix()/2 {
+			wait = time.Unix(reset, 0).Sub(now)
+		} else {
+			wait = time.Duration(reset) * time.Second
+		}
+	} else {
+		wait = time.Second
+	}
+
+	return max(0, min(wait, maxPageDelay))
+}
@@ -0,0 +1,92 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// redactedValue replaces secret material in logs, audit records, and VCR cassettes
+const redactedValue = "REDACTED"
+
+// minMaskedKeyLength is the shortest API key whose last characters may be logged for correlation;
+// shorter keys are fully redacted
+const minMaskedKeyLength = 24
+
+// redactedKeys lists lower-cased JSON key fragments whose values are never logged or recorded
+var redactedKeys = []string{"secret", "password", "presharedkey", "psk", "token", "apikey", "privatekey", "enrollmentkey"}
+
+// redactedHeaders lists the headers whose values are never logged
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// maskAPIKey returns a loggable form of an API key. Only the last four characters of long keys are kept.
+func maskAPIKey(apiKey string) string {
+	if len(apiKey) < minMaskedKeyLength {
+		return redactedValue
+	}
+	return redactedValue + "..." + apiKey[len(apiKey)-4:]
+}
+
+// RedactSecrets removes the API key and the values of secret-looking JSON keys, such as PSKs and RADIUS
+// shared secrets, from a request or response body before it is logged or recorded
+func RedactSecrets(body string, apiKey string) string {
+	if apiKey != "" {
+		body = strings.ReplaceAll(body, apiKey, redactedValue)
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(body), &parsed); err != nil {
+		return body
+	}
+
+	data, err := json.Marshal(redactJSON(parsed))
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// redactHeaders returns a copy of the headers with credentials replaced
+func redactHeaders(headers http.Header, apiKey string) http.Header {
+	redacted := headers.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) == "" {
+			continue
+		}
+		if name == "Authorization" {
+			redacted.Set(name, "Bearer "+maskAPIKey(apiKey))
+		} else {
+			redacted.Set(name, redactedValue)
+		}
+	}
+	return redacted
+}
+
+// redactJSON walks a decoded JSON value and replaces the values of secret-looking keys
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			lowerKey := strings.ToLower(key)
+			redacted := false
+			for _, fragment := range redactedKeys {
+				if strings.Contains(lowerKey, fragment) {
+					v[key] = redactedValue
+					redacted = true
+					break
+				}
+			}
+			if !redacted {
+				v[key] = redactJSON(item)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMakeRequestWithRetryRetryableErrors(t *testing.T) {
+	cases := []struct {
+		name         string
+		statuses     []int // Returned in order, the last one for all further requests
+		threshold    int
+		wantRequests int32
+		wantErr      bool
+		wantOpen     bool
+	}{
+		{"rate limiting is retried", []int{429, 429, 200}, 0, 3, false, false},
+		{"server errors are retried", []int{503, 500, 200}, 0, 3, false, false},
+		{"client errors are not retried", []int{400}, 0, 1, true, false},
+		{"all retries fail", []int{502}, 0, 4, true, false},
+		{"the circuit breaker stops retrying server errors", []int{503}, 2, 2, true, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1))
+				if n > len(tc.statuses) {
+					n = len(tc.statuses)
+				}
+				w.WriteHeader(tc.statuses[n-1])
+				w.Write([]byte(`{}`))
+			}))
+			defer httpServer.Close()
+
+			config := &Config{BaseURL: httpServer.URL, Retries: 4, BackoffStrategy: BackoffConstant, CircuitBreakerThreshold: tc.threshold}
+			_, err := config.MakeRequestWithRetry(context.Background(), "GET", "/api/vlans", nil)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("error = %v, want error %t", err, tc.wantErr)
+			}
+			var openErr *CircuitOpenError
+			if errors.As(err, &openErr) != tc.wantOpen {
+				t.Errorf("error = %v, want a CircuitOpenError %t", err, tc.wantOpen)
+			}
+			if got := requests.Load(); got != tc.wantRequests {
+				t.Errorf("sent %d requests, want %d", got, tc.wantRequests)
+			}
+		})
+	}
+}
+
+func TestMakeRequestWithRetryConnectionFailure(t *testing.T) {
+	httpServer := httptest.NewServer(http.NotFoundHandler())
+	baseURL := httpServer.URL
+	httpServer.Close()
+
+	// The first failure trips the breaker, so the retry fails fast instead of reaching the API again
+	config := &Config{BaseURL: baseURL, Retries: 3, BackoffStrategy: BackoffConstant, CircuitBreakerThreshold: 1}
+	_, err := config.MakeRequestWithRetry(context.Background(), "GET", "/api/vlans", nil)
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) {
+		t.Fatalf("error = %v, want the connection failure to be retried until the circuit breaker opens", err)
+	}
+}
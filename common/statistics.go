@@ -0,0 +1,172 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type statisticsResourceKey struct{}
+
+// unattributedStatistics is the resource type API calls are counted under when no resource operation made them,
+// such as the capability detection when the provider is configured
+const unattributedStatistics = "provider"
+
+// APICallStatistics counts the API calls made for one resource type
+type APICallStatistics struct {
+	Calls          int     `json:"calls"`
+	Retries        int     `json:"retries"`
+	RateLimited    int     `json:"rate_limited"`
+	Errors         int     `json:"errors"`
+	RequestSeconds float64 `json:"request_seconds"`
+	BackoffSeconds float64 `json:"backoff_seconds"`
+}
+
+func (s *APICallStatistics) add(other *APICallStatistics) {
+	s.Calls += other.Calls
+	s.Retries += other.Retries
+	s.RateLimited += other.RateLimited
+	s.Errors += other.Errors
+	s.RequestSeconds += other.RequestSeconds
+	s.BackoffSeconds += other.BackoffSeconds
+}
+
+// APIStatisticsReport is the summary written to StatisticsPath
+type APIStatisticsReport struct {
+	UpdatedAt     string                        `json:"updated_at"`
+	Total         APICallStatistics             `json:"total"`
+	ResourceTypes map[string]*APICallStatistics `json:"resource_types"`
+}
+
+// apiStatistics collects the API call statistics of the provider process, keyed by resource type
+type apiStatistics struct {
+	mu            sync.Mutex
+	resourceTypes map[string]*APICallStatistics
+}
+
+// WithStatisticsResource returns a context that counts the API calls made with it under a resource type, such as
+// portnox_mac_account or data.portnox_vendors
+func WithStatisticsResource(ctx context.Context, resourceType string) context.Context {
+	return context.WithValue(ctx, statisticsResourceKey{}, resourceType)
+}
+
+// statisticsEnabled reports whether API call statistics are collected
+func (c *Config) statisticsEnabled() bool {
+	return c.LogStatistics || c.StatisticsPath != ""
+}
+
+// CheckStatisticsPath verifies that the statistics file can be created and written
+func CheckStatisticsPath(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening api_statistics_path %s: %s", path, err)
+	}
+	return file.Close()
+}
+
+// recordStatistics updates the statistics of the resource type the request was made for
+func (c *Config) recordStatistics(ctx context.Context, update func(*APICallStatistics)) {
+	if !c.statisticsEnabled() {
+		return
+	}
+	resourceType, ok := ctx.Value(statisticsResourceKey{}).(string)
+	if !ok || resourceType == "" {
+		resourceType = unattributedStatistics
+	}
+
+	c.statisticsMu.Lock()
+	defer c.statisticsMu.Unlock()
+
+	if c.statistics == nil {
+		c.statistics = &apiStatistics{resourceTypes: make(map[string]*APICallStatistics)}
+	}
+	stats, ok := c.statistics.resourceTypes[resourceType]
+	if !ok {
+		stats = &APICallStatistics{}
+		c.statistics.resourceTypes[resourceType] = stats
+	}
+	update(stats)
+}
+
+// recordRequest counts one HTTP request, its duration, and whether it was rate limited or failed
+func (c *Config) recordRequest(ctx context.Context, elapsed time.Duration, statusCode int, err error) {
+	c.recordStatistics(ctx, func(stats *APICallStatistics) {
+		stats.Calls++
+		stats.RequestSeconds += elapsed.Seconds()
+		if statusCode == 429 {
+			stats.RateLimited++
+		}
+		if err != nil {
+			stats.Errors++
+		}
+	})
+}
+
+// recordRetry counts a retry and the backoff waited before it
+func (c *Config) recordRetry(ctx context.Context, wait time.Duration) {
+	c.recordStatistics(ctx, func(stats *APICallStatistics) {
+		stats.Retries++
+		stats.BackoffSeconds += wait.Seconds()
+	})
+}
+
+// StatisticsReport returns a copy of the API call statistics collected so far
+func (c *Config) StatisticsReport() APIStatisticsReport {
+	c.statisticsMu.Lock()
+	defer c.statisticsMu.Unlock()
+
+	report := APIStatisticsReport{
+		UpdatedAt:     time.Now().UTC().Format(time.RFC3339),
+		ResourceTypes: make(map[string]*APICallStatistics),
+	}
+	if c.statistics == nil {
+		return report
+	}
+	for resourceType, stats := range c.statistics.resourceTypes {
+		copied := *stats
+		report.ResourceTypes[resourceType] = &copied
+		report.Total.add(stats)
+	}
+	return report
+}
+
+// ReportStatistics logs a summary of the API call statistics collected so far and rewrites StatisticsPath with them.
+// It is called after every resource and data source operation, as the provider is not told when Terraform is done
+// with it, so the summary after the last operation covers the whole run.
+func (c *Config) ReportStatistics(operation string) {
+	if !c.statisticsEnabled() {
+		return
+	}
+	report := c.StatisticsReport()
+
+	if c.LogStatistics {
+		resourceTypes := make([]string, 0, len(report.ResourceTypes))
+		for resourceType, stats := range report.ResourceTypes {
+			resourceTypes = append(resourceTypes, fmt.Sprintf("%s=%d/%d/%d", resourceType, stats.Calls, stats.Retries, stats.RateLimited))
+		}
+		sort.Strings(resourceTypes)
+		log.Printf("[INFO] Portnox API statistics after %s: %d calls, %d retries, %d rate limited (429), %d errors, %.1fs in requests, %.1fs in backoff; calls/retries/429s by resource type: %s",
+			operation, report.Total.Calls, report.Total.Retries, report.Total.RateLimited, report.Total.Errors,
+			report.Total.RequestSeconds, report.Total.BackoffSeconds, strings.Join(resourceTypes, " "))
+	}
+
+	if c.StatisticsPath != "" {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Printf("[ERROR] Error encoding API statistics: %v", err)
+			return
+		}
+
+		c.statisticsMu.Lock()
+		defer c.statisticsMu.Unlock()
+		if err := os.WriteFile(c.StatisticsPath, append(content, '\n'), 0o600); err != nil {
+			log.Printf("[ERROR] Error writing API statistics to %s: %v", c.StatisticsPath, err)
+		}
+	}
+}
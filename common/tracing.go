@@ -0,0 +1,103 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// flushTimeout bounds how long an operation waits for its spans to be exported
+const flushTimeout = 2 * time.Second
+
+// tracerName identifies the spans of the provider in the exported traces
+const tracerName = "github.com/portnox-community/terraform-provider-portnox"
+
+var (
+	tracingOnce     sync.Once
+	tracingProvider *sdktrace.TracerProvider
+	tracingErr      error
+)
+
+// TracingEnabled reports whether an OTLP endpoint is configured through the standard OpenTelemetry environment
+// variables, which turns on trace export
+func TracingEnabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != ""
+}
+
+// InitTracing starts exporting traces over OTLP/HTTP to the endpoint set in the OpenTelemetry environment variables,
+// once per provider process. It does nothing when no endpoint is set, leaving the no-op tracer in place.
+func InitTracing(ctx context.Context, version string) error {
+	if !TracingEnabled() {
+		return nil
+	}
+
+	tracingOnce.Do(func() {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			tracingErr = fmt.Errorf("error creating the OTLP trace exporter: %s", err)
+			return
+		}
+
+		// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the default service name
+		res, err := resource.New(ctx,
+			resource.WithAttributes(
+				attribute.String("service.name", "terraform-provider-portnox"),
+				attribute.String("service.version", version),
+			),
+			resource.WithFromEnv(),
+		)
+		if err != nil {
+			tracingErr = fmt.Errorf("error creating the OpenTelemetry resource: %s", err)
+			return
+		}
+
+		tracingProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+		)
+		otel.SetTracerProvider(tracingProvider)
+	})
+
+	return tracingErr
+}
+
+// FlushTraces exports the spans ended so far. Terraform stops the provider without notice when it is done with it,
+// so spans are flushed after every operation instead of when the provider shuts down. The flush gives up after
+// flushTimeout, so an unreachable collector does not stall every operation; the spans it could not export are
+// dropped.
+func FlushTraces(ctx context.Context) {
+	if tracingProvider == nil {
+		return
+	}
+	// Export on a context of its own, so a cancelled operation still has its spans exported
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+	defer cancel()
+	if err := tracingProvider.ForceFlush(flushCtx); err != nil {
+		log.Printf("[WARN] Error exporting traces: %s", err)
+	}
+}
+
+// StartSpan starts a span as a child of the span in ctx, if any. It is a no-op span when tracing is not enabled.
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan records the error, if any, on a span and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// validateOnlyParam is the query parameter that asks the API to validate a mutation without applying it
+const validateOnlyParam = "validateOnly"
+
+type validatingKey struct{}
+
+type validateOnlyExemptKey struct{}
+
+// ValidateOnlyError is returned instead of a response when ValidateOnly is set and the API accepted a mutation
+// without applying it, so the resource stops before it relies on the change having been made. Resources report it
+// as a warning.
+type ValidateOnlyError struct {
+	Method   string
+	Endpoint string
+}
+
+func (e *ValidateOnlyError) Error() string {
+	return fmt.Sprintf("validate_only is set: the API accepted %s %s but no changes were made", e.Method, e.Endpoint)
+}
+
+// validateMutation sends a mutation with the validateOnly query parameter, with the usual chunking and retries,
+// and returns a ValidateOnlyError when the API accepts it. Errors returned by the API, such as validation
+// failures of the payload, are returned as they are. The response body is kept so per-entry whitelist failures
+// reported by the validation are not lost.
+func (c *Config) validateMutation(ctx context.Context, method, endpoint string, payload interface{}, headers map[string]string) ([]byte, http.Header, error) {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+
+	responseBody, responseHeaders, err := c.MakeRequestWithRetryAndHeaders(context.WithValue(ctx, validatingKey{}, true), method, endpoint+separator+validateOnlyParam+"=true", payload, headers)
+	if err != nil {
+		return responseBody, responseHeaders, err
+	}
+
+	return responseBody, responseHeaders, &ValidateOnlyError{Method: method, Endpoint: endpoint}
+}
+
+// isValidating reports whether a request is already being sent by validateMutation
+func isValidating(ctx context.Context) bool {
+	validating, _ := ctx.Value(validatingKey{}).(bool)
+	return validating
+}
+
+// WithoutValidateOnly returns a context whose requests are sent as they are when ValidateOnly is set. It is meant for
+// POST and DELETE requests that do not change the configuration of the tenant, such as issuing an API token or
+// generating a report, whose result the rest of the run depends on.
+func WithoutValidateOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, validateOnlyExemptKey{}, true)
+}
+
+// isValidateOnlyExempt reports whether the requests of a context are exempt from ValidateOnly
+func isValidateOnlyExempt(ctx context.Context) bool {
+	exempt, _ := ctx.Value(validateOnlyExemptKey{}).(bool)
+	return exempt
+}
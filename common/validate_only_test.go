@@ -0,0 +1,60 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestValidateOnly(t *testing.T) {
+	cases := []struct {
+		name          string
+		method        string
+		endpoint      string
+		exempt        bool
+		wantValidated bool
+		wantQuery     string
+	}{
+		{"mutations are only validated", "POST", "/api/vlans", false, true, "validateOnly=true"},
+		{"deletes are only validated", "DELETE", "/api/vlans/10", false, true, "validateOnly=true"},
+		{"searches are sent as they are", "POST", "/api/mac-based-accounts/search", false, false, ""},
+		{"exempt requests are sent as they are", "POST", "/api/tokens", true, false, ""},
+		{"exempt deletes are sent as they are", "DELETE", "/api/tokens/abc", true, false, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var queries []string
+			httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				queries = append(queries, r.URL.RawQuery)
+				mu.Unlock()
+				fmt.Fprint(w, `{}`)
+			}))
+			defer httpServer.Close()
+
+			config := &Config{BaseURL: httpServer.URL, Retries: 1, ValidateOnly: true}
+			ctx := context.Background()
+			if tc.exempt {
+				ctx = WithoutValidateOnly(ctx)
+			}
+
+			_, err := config.MakeRequestWithRetry(ctx, tc.method, tc.endpoint, map[string]interface{}{})
+			var validateErr *ValidateOnlyError
+			if validated := errors.As(err, &validateErr); validated != tc.wantValidated {
+				t.Fatalf("error = %v, want a ValidateOnlyError %t", err, tc.wantValidated)
+			}
+			if !tc.wantValidated && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(queries) != 1 || queries[0] != tc.wantQuery {
+				t.Errorf("queries = %q, want one request with %q", queries, tc.wantQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	VCRModeRecord = "record"
+	VCRModeReplay = "replay"
+)
+
+// VCRInteraction is a single sanitized request/response pair stored in a cassette file
+type VCRInteraction struct {
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	RequestBody  string `json:"request_body"`
+	StatusCode   int    `json:"status_code"`
+	Status       string `json:"status"`
+	ResponseBody string `json:"response_body"`
+}
+
+// VCRTransport records API traffic to a cassette file or replays it from one, so request and
+// response handling can be exercised without access to a Portnox tenant
+type VCRTransport struct {
+	mode     string
+	cassette string
+	apiKey   string
+	next     http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []VCRInteraction
+	used         []bool
+}
+
+// NewVCRTransport returns a transport in record or replay mode backed by the given cassette file.
+// In replay mode the cassette must already exist.
+func NewVCRTransport(mode, cassette, apiKey string) (*VCRTransport, error) {
+	if cassette == "" {
+		return nil, fmt.Errorf("a cassette path must be provided for VCR mode %q", mode)
+	}
+
+	t := &VCRTransport{
+		mode:     mode,
+		cassette: cassette,
+		apiKey:   apiKey,
+		next:     http.DefaultTransport,
+	}
+
+	switch mode {
+	case VCRModeRecord:
+		t.interactions = []VCRInteraction{}
+	case VCRModeReplay:
+		data, err := os.ReadFile(cassette)
+		if err != nil {
+			return nil, fmt.Errorf("error reading VCR cassette %s: %s", cassette, err)
+		}
+		if err := json.Unmarshal(data, &t.interactions); err != nil {
+			return nil, fmt.Errorf("error parsing VCR cassette %s: %s", cassette, err)
+		}
+		t.used = make([]bool, len(t.interactions))
+	default:
+		return nil, fmt.Errorf("unsupported VCR mode %q, expected %q or %q", mode, VCRModeRecord, VCRModeReplay)
+	}
+
+	return t, nil
+}
+
+func (t *VCRTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody := ""
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(data))
+		requestBody = t.sanitize(string(data))
+	}
+
+	if t.mode == VCRModeReplay {
+		return t.replay(req, requestBody)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interactions = append(t.interactions, VCRInteraction{
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		RequestBody:  requestBody,
+		StatusCode:   resp.StatusCode,
+		Status:       resp.Status,
+		ResponseBody: t.sanitize(string(responseBody)),
+	})
+
+	// Rewrite the cassette after every interaction so a failed run still leaves usable fixtures
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(t.cassette, data, 0o600); err != nil {
+		return nil, fmt.Errorf("error writing VCR cassette %s: %s", t.cassette, err)
+	}
+
+	return resp, nil
+}
+
+// replay returns the first unused recorded interaction matching the request method, path, and body
+func (t *VCRTransport) replay(req *http.Request, requestBody string) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.interactions {
+		if t.used[i] || interaction.Method != req.Method || interaction.Path != req.URL.Path || interaction.RequestBody != requestBody {
+			continue
+		}
+		t.used[i] = true
+
+		return &http.Response{
+			Status:     interaction.Status,
+			StatusCode: interaction.StatusCode,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+			Request:    req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction in %s matches %s %s", t.cassette, req.Method, req.URL.Path)
+}
+
+// sanitize removes the API key and the values of secret-looking JSON keys from a request or response body
+func (t *VCRTransport) sanitize(body string) string {
+	return RedactSecrets(body, t.apiKey)
+}
@@ -0,0 +1,153 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// WhitelistItemError reports a MAC address that the API rejected from a whitelist-add batch whose other
+// entries were accepted
+type WhitelistItemError struct {
+	Mac    string
+	Reason string
+}
+
+func (e *WhitelistItemError) Error() string {
+	return fmt.Sprintf("MAC address %s was rejected: %s", e.Mac, e.Reason)
+}
+
+// whitelistItemResults returns the MAC addresses a whitelist-add response reports as rejected, mapped to the reason,
+// and those it reports as added. The API reports per-item outcomes as a Results array of {Mac, Success, Error}
+// objects, both in successful responses and in error responses. ok is false when the response has no per-item
+// results.
+func whitelistItemResults(responseBody []byte) (failures map[string]string, added map[string]bool, ok bool) {
+	var response struct {
+		Results []struct {
+			Mac     string `json:"Mac"`
+			Success *bool  `json:"Success"`
+			Error   string `json:"Error"`
+		} `json:"Results"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil || len(response.Results) == 0 {
+		return nil, nil, false
+	}
+
+	failures = make(map[string]string)
+	added = make(map[string]bool)
+	for _, result := range response.Results {
+		if result.Mac == "" {
+			continue
+		}
+		if result.Error == "" && result.Success != nil && *result.Success {
+			added[result.Mac] = true
+			continue
+		}
+		if result.Error == "" && result.Success == nil {
+			continue
+		}
+		reason := result.Error
+		if reason == "" {
+			reason = "rejected by the API without a reason"
+		}
+		failures[result.Mac] = reason
+	}
+	return failures, added, true
+}
+
+// WhitelistAddFailures returns the entries of a whitelist-add request that were not added, mapped to the reason. When
+// the request succeeded, these are the entries the per-item results report as rejected. When it failed, only the
+// entries the results explicitly report as added were added, and the others failed with the request error. It
+// returns nil when the response has no per-item results, or when the request failed and no entry was reported as
+// added, so the caller reports the request error for the whole batch.
+func WhitelistAddFailures(entries []map[string]interface{}, responseBody []byte, err error) map[string]string {
+	failures, added, ok := whitelistItemResults(responseBody)
+	if !ok || err == nil {
+		return failures
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	for _, entry := range entries {
+		mac, _ := entry["Mac"].(string)
+		if _, failed := failures[mac]; !failed && !added[mac] {
+			failures[mac] = err.Error()
+		}
+	}
+	return failures
+}
+
+// whitelistAddResult returns the outcome of a whitelist-add request for one entry: nil when the entry was
+// added, a WhitelistItemError when the per-item results report it as rejected, or the request error otherwise.
+// When the request failed, the entry only counts as added if the per-item results say so.
+func whitelistAddResult(entry map[string]interface{}, responseBody []byte, err error) error {
+	failures, added, ok := whitelistItemResults(responseBody)
+	if !ok {
+		return err
+	}
+	mac, _ := entry["Mac"].(string)
+	if reason, failed := failures[mac]; failed {
+		return &WhitelistItemError{Mac: mac, Reason: reason}
+	}
+	if err != nil && !added[mac] {
+		return err
+	}
+	return nil
+}
+
+// MacWhiteList is the MAC whitelist of an account response. The API returns it either as an array of entries or,
+// before API version 2, as an object with an _items array; both decode into Entries, and Format records which of the
+// two the response used. A missing or null whitelist decodes as empty with an unknown format.
+type MacWhiteList struct {
+	Entries []map[string]interface{}
+	Format  WhitelistFormat
+}
+
+// UnmarshalJSON decodes either whitelist format, skipping null entries
+func (l *MacWhiteList) UnmarshalJSON(data []byte) error {
+	var entries []map[string]interface{}
+	switch trimmed := bytes.TrimSpace(data); {
+	case bytes.Equal(trimmed, []byte("null")):
+		*l = MacWhiteList{}
+		return nil
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return err
+		}
+		l.Format = WhitelistFormatArray
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var items struct {
+			Items []map[string]interface{} `json:"_items"`
+		}
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return err
+		}
+		entries = items.Items
+		l.Format = WhitelistFormatItems
+	default:
+		return fmt.Errorf("unexpected MAC whitelist format: %s", trimmed)
+	}
+
+	l.Entries = make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		if entry != nil {
+			l.Entries = append(l.Entries, entry)
+		}
+	}
+	return nil
+}
+
+// MacAccount is a MAC-based account as returned by the account and search endpoints, decoded into the fields the
+// whitelist code reads
+type MacAccount struct {
+	AccountId        string `json:"AccountId"`
+	AccountName      string `json:"AccountName"`
+	AgentlessOptions *struct {
+		MacWhiteList MacWhiteList `json:"MacWhiteList"`
+	} `json:"AgentlessOptions"`
+}
+
+// MacAccountSearchResponse is the response of the account search endpoint
+type MacAccountSearchResponse struct {
+	Accounts []MacAccount `json:"Accounts"`
+}
@@ -0,0 +1,34 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// AuditResource wraps the create, update, and delete functions of a resource so the API mutations they make are
+// attributed to the resource type and ID in the provider audit trail. Terraform does not send the full resource
+// address to providers, so the type and ID identify the resource instead.
+func AuditResource(resourceType string, r *schema.Resource) *schema.Resource {
+	wrap := func(operation schema.CreateContextFunc) schema.CreateContextFunc {
+		if operation == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			resource := resourceType
+			if d.Id() != "" {
+				resource += "." + d.Id()
+			}
+			return operation(common.WithAuditResource(ctx, resource), d, m)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.UpdateContext = schema.UpdateContextFunc(wrap(schema.CreateContextFunc(r.UpdateContext)))
+	r.DeleteContext = schema.DeleteContextFunc(wrap(schema.CreateContextFunc(r.DeleteContext)))
+
+	return r
+}
@@ -0,0 +1,183 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// activeSessionFilters maps the filter attributes of the data source to the query parameters of the API
+var activeSessionFilters = map[string]string{
+	"nas_id":       "nasId",
+	"site_id":      "siteId",
+	"account_name": "accountName",
+	"ssid":         "ssid",
+}
+
+func DataSourceActiveSessions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceActiveSessionsRead,
+		Schema: map[string]*schema.Schema{
+			"nas_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return sessions authenticated through this NAS device.",
+			},
+			"site_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return sessions at this site.",
+			},
+			"account_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return sessions of this account.",
+			},
+			"ssid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return sessions connected to this SSID.",
+			},
+			"sessions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"session_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the session.",
+						},
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The MAC address of the device.",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The authenticated username, if any.",
+						},
+						"account_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the account the session authenticated as.",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the device.",
+						},
+						"vlan": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VLAN assigned to the session.",
+						},
+						"nas_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the NAS device the session authenticated through.",
+						},
+						"nas_ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the NAS device.",
+						},
+						"nas_port": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The NAS port (switch interface) of the session.",
+						},
+						"ssid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The SSID of wireless sessions.",
+						},
+						"site_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the site of the session.",
+						},
+						"start_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the session started.",
+						},
+					},
+				},
+				Description: "The active sessions matching the filters.",
+			},
+		},
+	}
+}
+
+func dataSourceActiveSessionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	query := url.Values{}
+	for attribute, parameter := range activeSessionFilters {
+		if value := d.Get(attribute).(string); value != "" {
+			query.Set(parameter, value)
+		}
+	}
+
+	endpoint := "/api/sessions/active"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, endpoint)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var response struct {
+		Sessions []struct {
+			SessionId    string `json:"SessionId"`
+			MacAddress   string `json:"MacAddress"`
+			Username     string `json:"Username"`
+			AccountName  string `json:"AccountName"`
+			IpAddress    string `json:"IpAddress"`
+			Vlan         string `json:"Vlan"`
+			NasId        string `json:"NasId"`
+			NasIpAddress string `json:"NasIpAddress"`
+			NasPort      string `json:"NasPort"`
+			Ssid         string `json:"Ssid"`
+			SiteId       string `json:"SiteId"`
+			StartTime    string `json:"StartTime"`
+		} `json:"Sessions"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return diag.FromErr(err)
+	}
+
+	sessions := make([]map[string]interface{}, 0, len(response.Sessions))
+	for _, session := range response.Sessions {
+		sessions = append(sessions, map[string]interface{}{
+			"session_id":     session.SessionId,
+			"mac_address":    session.MacAddress,
+			"username":       session.Username,
+			"account_name":   session.AccountName,
+			"ip_address":     session.IpAddress,
+			"vlan":           session.Vlan,
+			"nas_id":         session.NasId,
+			"nas_ip_address": session.NasIpAddress,
+			"nas_port":       session.NasPort,
+			"ssid":           session.Ssid,
+			"site_id":        session.SiteId,
+			"start_time":     session.StartTime,
+		})
+	}
+
+	d.SetId(endpoint)
+	if err := d.Set("sessions", sessions); err != nil {
+		return diag.Errorf("error setting sessions: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// eventsPageSize is the number of events requested per page
+const eventsPageSize = 200
+
+func DataSourceEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEventsRead,
+		Schema: map[string]*schema.Schema{
+			"start_time": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return events at or after this time, in RFC 3339 format.",
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return events before this time, in RFC 3339 format. Defaults to now.",
+			},
+			"category": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"admin", "authentication", "system"}, false),
+				Description:  "Only return events in this category. One of admin, authentication, or system.",
+			},
+			"severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"info", "warning", "error", "critical"}, false),
+				Description:  "Only return events of this severity. One of info, warning, error, or critical.",
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of events to return. 0 returns all matching events.",
+			},
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the event.",
+						},
+						"time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time of the event.",
+						},
+						"category": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The category of the event.",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The severity of the event.",
+						},
+						"actor": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The administrator, user, or device that caused the event.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the event.",
+						},
+					},
+				},
+				Description: "The events matching the filters, oldest first.",
+			},
+		},
+	}
+}
+
+func dataSourceEventsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	query := url.Values{}
+	query.Set("from", d.Get("start_time").(string))
+	if endTime := d.Get("end_time").(string); endTime != "" {
+		query.Set("to", endTime)
+	}
+	if category := d.Get("category").(string); category != "" {
+		query.Set("category", category)
+	}
+	if severity := d.Get("severity").(string); severity != "" {
+		query.Set("severity", severity)
+	}
+	query.Set("pageSize", strconv.Itoa(eventsPageSize))
+
+	maxResults := d.Get("max_results").(int)
+	events := make([]map[string]interface{}, 0)
+
+	// Follow the pages until a short page is returned or enough events are collected
+	err := config.Paginate(ctx, "GET", "/api/events?"+query.Encode(), "page", func(page int, responseBody []byte) (bool, error) {
+		var response struct {
+			Events []struct {
+				Id       string `json:"Id"`
+				Time     string `json:"Time"`
+				Category string `json:"Category"`
+				Severity string `json:"Severity"`
+				Actor    string `json:"Actor"`
+				Message  string `json:"Message"`
+			} `json:"Events"`
+		}
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return false, err
+		}
+
+		for _, event := range response.Events {
+			if maxResults > 0 && len(events) >= maxResults {
+				break
+			}
+			events = append(events, map[string]interface{}{
+				"id":       event.Id,
+				"time":     event.Time,
+				"category": event.Category,
+				"severity": event.Severity,
+				"actor":    event.Actor,
+				"message":  event.Message,
+			})
+		}
+
+		return len(response.Events) >= eventsPageSize && (maxResults == 0 || len(events) < maxResults), nil
+	})
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	d.SetId(query.Encode())
+	if err := d.Set("events", events); err != nil {
+		return diag.Errorf("error setting events: %s", err)
+	}
+
+	return nil
+}
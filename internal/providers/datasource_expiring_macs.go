@@ -0,0 +1,194 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceExpiringMacs lists the whitelist entries that expire within a window, for use in check blocks that
+// warn before device access lapses
+func DataSourceExpiringMacs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceExpiringMacsRead,
+		Schema: map[string]*schema.Schema{
+			"account_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return entries of this MAC-based account. All accounts are searched when not set.",
+			},
+			"within": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "30d",
+				ValidateFunc: validateDurationWithDays,
+				Description:  "How far ahead to look for expirations, as a duration such as 14d or 72h. Defaults to 30d.",
+			},
+			"include_expired": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also return entries that have already expired.",
+			},
+			"entries": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the MAC-based account whitelisting the MAC address.",
+						},
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The MAC address in the whitelist.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the MAC address.",
+						},
+						"expiration": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expiration date/time of the MAC address.",
+						},
+						"expires_in_days": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of whole days until the entry expires, 0 when it expires within a day or has expired.",
+						},
+						"expired": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Indicates if the entry has already expired.",
+						},
+					},
+				},
+				Description: "The matching whitelist entries, ordered by expiration.",
+			},
+			"macs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The MAC addresses of the matching whitelist entries.",
+			},
+			"entry_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of matching whitelist entries.",
+			},
+		},
+	}
+}
+
+func dataSourceExpiringMacsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	accountName := d.Get("account_name").(string)
+	within := d.Get("within").(string)
+	includeExpired := d.Get("include_expired").(bool)
+
+	window, err := parseDurationWithDays(within)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var accounts []common.MacAccount
+	if accountName != "" {
+		responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/mac-based-accounts/"+accountName)
+		if err != nil {
+			return apiErrorDiagnostics(err, "account_name")
+		}
+		var account common.MacAccount
+		if err := json.Unmarshal(responseBody, &account); err != nil {
+			return diag.FromErr(err)
+		}
+		accounts = append(accounts, account)
+	} else {
+		responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts/search", map[string]interface{}{})
+		if err != nil {
+			return apiErrorDiagnostics(err, "")
+		}
+		var response common.MacAccountSearchResponse
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return diag.FromErr(err)
+		}
+		accounts = response.Accounts
+	}
+
+	now := time.Now()
+	type expiringEntry struct {
+		expiration time.Time
+		values     map[string]interface{}
+	}
+	matches := make([]expiringEntry, 0)
+	for _, account := range accounts {
+		name := account.AccountName
+		if name == "" {
+			name = accountName
+		}
+
+		for _, macEntry := range accountMacWhiteList(config, account) {
+			macAddress, _ := macEntry["Mac"].(string)
+			value, _ := macEntry["Expiration"].(string)
+			// Entries without an expiration, or with one that cannot be parsed, never expire
+			expiration, err := time.Parse(time.RFC3339, value)
+			if macAddress == "" || err != nil {
+				continue
+			}
+
+			expired := !expiration.After(now)
+			if (expired && !includeExpired) || !expiration.Before(now.Add(window)) {
+				continue
+			}
+
+			description, _ := macEntry["Description"].(string)
+			matches = append(matches, expiringEntry{
+				expiration: expiration,
+				values: map[string]interface{}{
+					"account_name":    name,
+					"mac_address":     macAddress,
+					"description":     description,
+					"expiration":      value,
+					"expires_in_days": int(math.Max(0, math.Floor(expiration.Sub(now).Hours()/24))),
+					"expired":         expired,
+				},
+			})
+		}
+	}
+
+	// Soonest first, so check blocks and outputs show the most urgent entries at the top
+	sort.SliceStable(matches, func(i, j int) bool {
+		if !matches[i].expiration.Equal(matches[j].expiration) {
+			return matches[i].expiration.Before(matches[j].expiration)
+		}
+		return matches[i].values["mac_address"].(string) < matches[j].values["mac_address"].(string)
+	})
+
+	entries := make([]map[string]interface{}, 0, len(matches))
+	macs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		entries = append(entries, match.values)
+		macs = append(macs, match.values["mac_address"].(string))
+	}
+
+	d.SetId(strings.Join([]string{accountName, within}, ","))
+	if err := d.Set("entries", entries); err != nil {
+		return diag.Errorf("error setting entries: %s", err)
+	}
+	d.Set("macs", macs)
+	d.Set("entry_count", len(entries))
+
+	return nil
+}
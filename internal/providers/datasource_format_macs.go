@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceFormatMacs converts a list of MAC addresses to a single notation without calling the API, so the MAC
+// addresses managed in Portnox can feed systems that expect another notation, such as DHCP reservations or switch
+// configurations. The plugin SDK does not support provider-defined functions, so the conversion is exposed as a data
+// source.
+func DataSourceFormatMacs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFormatMacsRead,
+		Schema: map[string]*schema.Schema{
+			"mac_addresses": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The MAC addresses to convert, in colon, dash, dotted, or bare notation.",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "colon",
+				ValidateFunc: validation.StringInSlice(macAddressFormats, false),
+				Description:  "The notation to convert the MAC addresses to: colon, dash, dotted, or bare.",
+			},
+			"case": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "upper",
+				ValidateFunc: validation.StringInSlice([]string{"upper", "lower"}, false),
+				Description:  "The letter case of the converted MAC addresses: upper or lower.",
+			},
+			"formatted": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The converted MAC addresses, in the order of mac_addresses.",
+			},
+			"formatted_map": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The converted MAC addresses, keyed by the MAC addresses as given in mac_addresses.",
+			},
+		},
+	}
+}
+
+func dataSourceFormatMacsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	format := d.Get("format").(string)
+	letterCase := d.Get("case").(string)
+
+	macAddresses := d.Get("mac_addresses").([]interface{})
+	formatted := make([]string, 0, len(macAddresses))
+	formattedMap := make(map[string]string, len(macAddresses))
+	for i, mac := range macAddresses {
+		macStr, _ := mac.(string)
+		if !macAddressPattern.MatchString(strings.TrimSpace(macStr)) {
+			return diag.Errorf("mac_addresses[%d] %q %s", i, macStr, macAddressMessage)
+		}
+		digits, _ := macHex(macStr)
+		value := formatMacAddress(digits, format, letterCase == "lower")
+		formatted = append(formatted, value)
+		formattedMap[macStr] = value
+	}
+
+	d.SetId(strings.Join([]string{format, letterCase}, ","))
+	if err := d.Set("formatted", formatted); err != nil {
+		return diag.Errorf("error setting formatted: %s", err)
+	}
+	if err := d.Set("formatted_map", formattedMap); err != nil {
+		return diag.Errorf("error setting formatted_map: %s", err)
+	}
+
+	return nil
+}
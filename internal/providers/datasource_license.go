@@ -0,0 +1,124 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceLicense() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLicenseRead,
+		Schema: map[string]*schema.Schema{
+			"total_seats": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of licensed seats across all products.",
+			},
+			"consumed_seats": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of seats in use across all products.",
+			},
+			"expiration_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The earliest expiration date of the licensed products.",
+			},
+			"products": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"product": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The licensed product, such as NAC, ZTNA, or TACACS.",
+						},
+						"licensed": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of licensed seats for the product.",
+						},
+						"consumed": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of seats in use for the product.",
+						},
+						"utilization": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The share of licensed seats in use for the product, between 0 and 1.",
+						},
+						"expiration_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expiration date of the product license.",
+						},
+					},
+				},
+				Description: "The license usage per product.",
+			},
+		},
+	}
+}
+
+func dataSourceLicenseRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/license")
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var response struct {
+		OrgId    string `json:"OrgId"`
+		Products []struct {
+			Product        string `json:"Product"`
+			Licensed       int    `json:"Licensed"`
+			Consumed       int    `json:"Consumed"`
+			ExpirationDate string `json:"ExpirationDate"`
+		} `json:"Products"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var totalSeats, consumedSeats int
+	var expirationDate string
+	products := make([]map[string]interface{}, 0, len(response.Products))
+	for _, product := range response.Products {
+		utilization := 0.0
+		if product.Licensed > 0 {
+			utilization = float64(product.Consumed) / float64(product.Licensed)
+		}
+		totalSeats += product.Licensed
+		consumedSeats += product.Consumed
+		// Dates are ISO 8601, so they compare chronologically as strings
+		if product.ExpirationDate != "" && (expirationDate == "" || product.ExpirationDate < expirationDate) {
+			expirationDate = product.ExpirationDate
+		}
+
+		products = append(products, map[string]interface{}{
+			"product":         product.Product,
+			"licensed":        product.Licensed,
+			"consumed":        product.Consumed,
+			"utilization":     utilization,
+			"expiration_date": product.ExpirationDate,
+		})
+	}
+
+	d.SetId(response.OrgId)
+	d.Set("total_seats", totalSeats)
+	d.Set("consumed_seats", consumedSeats)
+	d.Set("expiration_date", expirationDate)
+	if err := d.Set("products", products); err != nil {
+		return diag.Errorf("error setting products: %s", err)
+	}
+
+	return nil
+}
@@ -79,6 +79,21 @@ func DataSourceMacAccount() *schema.Resource {
 							Computed:    true,
 							Description: "The expiration date/time of the MAC address.",
 						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the MAC address was added to the whitelist.",
+						},
+						"created_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The administrator or API key that added the MAC address.",
+						},
+						"last_seen": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the device last connected, if it has connected.",
+						},
 					},
 				},
 				Description: "A list of MAC addresses in the whitelist with their descriptions and expiration dates.",
@@ -123,9 +138,9 @@ func dataSourceMacAccountRead(ctx context.Context, d *schema.ResourceData, m int
 
 	accountID := d.Get("account_id").(string)
 
-	responseBody, err := config.MakeRequestWithRetry("GET", "/api/mac-based-accounts/"+accountID, nil)
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/mac-based-accounts/"+accountID)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, "account_id")
 	}
 
 	// Parse the response and update the state
@@ -145,49 +160,47 @@ func dataSourceMacAccountRead(ctx context.Context, d *schema.ResourceData, m int
 	d.Set("is_block_by_admin", accountData["IsBlockByAdmin"])
 	d.Set("org_id", accountData["OrgId"]) // Parse AgentlessOptions
 	if agentlessOptions, ok := accountData["AgentlessOptions"].(map[string]interface{}); ok {
-		// Parse MacWhiteList with full details
-		if macWhiteList, ok := agentlessOptions["MacWhiteList"].([]interface{}); ok {
-			macDetailsList := make([]map[string]interface{}, 0)
+		// Parse MacWhiteList with full details, in either of the formats the API returns it in
+		var account common.MacAccount
+		if err := json.Unmarshal(responseBody, &account); err != nil {
+			return diag.FromErr(err)
+		}
+		macDetailsList := make([]map[string]interface{}, 0)
 
-			// Process each MAC address entry
-			for _, item := range macWhiteList {
-				if item == nil {
-					continue
-				}
+		// Process each MAC address entry
+		for _, macEntry := range accountMacWhiteList(config, account) {
+			// Skip entries without a MAC address
+			macAddress, hasMac := macEntry["Mac"].(string)
+			if !hasMac || macAddress == "" {
+				continue
+			}
 
-				if macEntry, ok := item.(map[string]interface{}); ok {
-					// Skip entries without a MAC address
-					macAddress, hasMac := macEntry["Mac"].(string)
-					if !hasMac || macAddress == "" {
-						continue
-					}
+			// Create a new entry with standardized field names
+			newEntry := map[string]interface{}{
+				"mac_address": macAddress,
+			}
 
-					// Create a new entry with standardized field names
-					newEntry := map[string]interface{}{
-						"mac_address": macAddress,
-					}
+			// Handle description (may be null)
+			if desc, ok := macEntry["Description"].(string); ok {
+				newEntry["description"] = desc
+			} else {
+				newEntry["description"] = ""
+			}
 
-					// Handle description (may be null)
-					if desc, ok := macEntry["Description"].(string); ok {
-						newEntry["description"] = desc
-					} else {
-						newEntry["description"] = ""
-					}
+			// Handle expiration (may be null)
+			if exp, ok := macEntry["Expiration"].(string); ok && exp != "" {
+				newEntry["expiration"] = exp
+			} else {
+				newEntry["expiration"] = ""
+			}
 
-					// Handle expiration (may be null)
-					if exp, ok := macEntry["Expiration"].(string); ok && exp != "" {
-						newEntry["expiration"] = exp
-					} else {
-						newEntry["expiration"] = ""
-					}
+			setWhitelistEntryMetadata(newEntry, macEntry)
 
-					macDetailsList = append(macDetailsList, newEntry)
-				}
-			}
+			macDetailsList = append(macDetailsList, newEntry)
+		}
 
-			if err := d.Set("mac_whitelist", macDetailsList); err != nil {
-				return diag.Errorf("error setting mac_whitelist: %s", err)
-			}
+		if err := d.Set("mac_whitelist", macDetailsList); err != nil {
+			return diag.Errorf("error setting mac_whitelist: %s", err)
 		}
 		// Parse SecureMabOptions
 		if secureMabOptions, ok := agentlessOptions["SecureMabOptions"].(map[string]interface{}); ok {
@@ -0,0 +1,162 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceMacAccountAddresses reads only the whitelist entries of a MAC-based account, optionally filtered
+func DataSourceMacAccountAddresses() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMacAccountAddressesRead,
+		Schema: map[string]*schema.Schema{
+			"account_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the MAC-based account.",
+			},
+			"description_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return entries whose description starts with this prefix.",
+			},
+			"oui": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if _, ok := macHex(v.(string)); !ok {
+						errs = append(errs, fmt.Errorf("%s must be an OUI or MAC prefix (e.g. AA:BB:CC or AABBCC)", k))
+					}
+					return
+				},
+				Description: "Only return entries whose MAC address starts with this OUI or prefix.",
+			},
+			"mac_addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The MAC address in the whitelist.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the MAC address, without the owner and ticket.",
+						},
+						"owner": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The owner stored in the description, if any.",
+						},
+						"ticket": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ticket reference stored in the description, if any.",
+						},
+						"expiration": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expiration date/time of the MAC address.",
+						},
+						"vlan": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VLAN assigned to the device instead of the account VLAN, if any.",
+						},
+						"voice": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Indicates if the device is placed on the voice VLAN.",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the MAC address was added to the whitelist.",
+						},
+						"created_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The administrator or API key that added the MAC address.",
+						},
+						"last_seen": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the device last connected, if it has connected.",
+						},
+					},
+				},
+				Description: "The matching whitelist entries.",
+			},
+			"macs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The MAC addresses of the matching whitelist entries.",
+			},
+		},
+	}
+}
+
+func dataSourceMacAccountAddressesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	accountName := d.Get("account_name").(string)
+	descriptionPrefix := d.Get("description_prefix").(string)
+	oui := d.Get("oui").(string)
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/mac-based-accounts/"+accountName)
+	if err != nil {
+		return apiErrorDiagnostics(err, "account_name")
+	}
+
+	var account common.MacAccount
+	if err := json.Unmarshal(responseBody, &account); err != nil {
+		return diag.FromErr(err)
+	}
+
+	macAddresses := make([]map[string]interface{}, 0)
+	macs := make([]string, 0)
+	for _, macEntry := range accountMacWhiteList(config, account) {
+		macAddress, _ := macEntry["Mac"].(string)
+		if macAddress == "" {
+			continue
+		}
+		description, _ := macEntry["Description"].(string)
+		if descriptionPrefix != "" && !strings.HasPrefix(description, descriptionPrefix) {
+			continue
+		}
+		if oui != "" && !macMatchesPrefix(macAddress, oui) {
+			continue
+		}
+
+		expiration, _ := macEntry["Expiration"].(string)
+		entry := map[string]interface{}{
+			"mac_address": macAddress,
+			"expiration":  expiration,
+		}
+		setWhitelistEntryDescription(entry, macEntry)
+		setWhitelistEntryAssignment(entry, macEntry)
+		setWhitelistEntryMetadata(entry, macEntry)
+
+		macAddresses = append(macAddresses, entry)
+		macs = append(macs, macAddress)
+	}
+
+	d.SetId(strings.Join([]string{accountName, descriptionPrefix, oui}, ","))
+	if err := d.Set("mac_addresses", macAddresses); err != nil {
+		return diag.Errorf("error setting mac_addresses: %s", err)
+	}
+	d.Set("macs", macs)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceMacInOui tests a MAC address against a list of OUI/prefixes without calling the API. It also reports the
+// matched prefix, which the provider::portnox::mac_in_oui function does not.
+func DataSourceMacInOui() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMacInOuiRead,
+		Schema: map[string]*schema.Schema{
+			"mac_address": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The MAC address to test, in colon, dash, dotted, or bare notation.",
+			},
+			"prefixes": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of OUIs or MAC prefixes (e.g. AA:BB:CC or AABBCC) to test the MAC address against.",
+			},
+			"matches": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates if the MAC address falls within any of the given prefixes.",
+			},
+			"matched_prefix": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The first prefix from the list that matched the MAC address, or an empty string.",
+			},
+		},
+	}
+}
+
+func dataSourceMacInOuiRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	macAddress := d.Get("mac_address").(string)
+
+	macDigits, ok := macHex(macAddress)
+	if !ok || len(macDigits) != 12 {
+		return diag.Errorf("%q is not a valid MAC address", macAddress)
+	}
+
+	prefixes := make([]string, 0)
+	for _, prefix := range d.Get("prefixes").([]interface{}) {
+		prefixStr, _ := prefix.(string)
+		prefixes = append(prefixes, prefixStr)
+	}
+	matchedPrefix, err := matchOuiPrefix(macAddress, prefixes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(macDigits)
+	d.Set("matches", matchedPrefix != "")
+	d.Set("matched_prefix", matchedPrefix)
+
+	return nil
+}
+
+// matchOuiPrefix returns the first prefix the MAC address falls within, or an empty string when none matches. Every
+// prefix is validated, so a typo is reported even when an earlier prefix matches.
+func matchOuiPrefix(macAddress string, prefixes []string) (string, error) {
+	matchedPrefix := ""
+	for _, prefix := range prefixes {
+		if _, ok := macHex(prefix); !ok {
+			return "", fmt.Errorf("%q is not a valid OUI or MAC prefix", prefix)
+		}
+		if matchedPrefix == "" && macMatchesPrefix(macAddress, prefix) {
+			matchedPrefix = prefix
+		}
+	}
+	return matchedPrefix, nil
+}
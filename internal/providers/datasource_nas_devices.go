@@ -0,0 +1,160 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceNasDevices lists the registered NAS devices, optionally filtered by site, vendor, and IP range, so
+// switch configuration templates can enumerate them
+func DataSourceNasDevices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNasDevicesRead,
+		Schema: map[string]*schema.Schema{
+			"site": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return NAS devices registered in this site.",
+			},
+			"vendor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return NAS devices of this vendor. Matching is case-insensitive.",
+			},
+			"ip_range": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsCIDR,
+				Description:  "Only return NAS devices whose IP address is in this range, in CIDR notation (e.g. 10.10.0.0/16).",
+			},
+			"devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the NAS device.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the NAS device.",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address the NAS device sends RADIUS requests from.",
+						},
+						"vendor": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The vendor of the NAS device.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A description of the NAS device.",
+						},
+						"site": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The site the NAS device is registered in.",
+						},
+						"shared_secret_source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Where the RADIUS shared secret of the device comes from: organization for the shared secret of the organization, or device for a secret of its own.",
+						},
+						"shared_secret_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the shared secret of the device, for looking it up without exposing it. Empty when the device uses the shared secret of the organization.",
+						},
+					},
+				},
+				Description: "The matching NAS devices.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching NAS devices.",
+			},
+		},
+	}
+}
+
+func dataSourceNasDevicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	site := d.Get("site").(string)
+	vendor := d.Get("vendor").(string)
+	ipRange := d.Get("ip_range").(string)
+
+	endpoint := "/api/nas-devices"
+	if site != "" {
+		endpoint += "?" + url.Values{"site": {site}}.Encode()
+	}
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, endpoint)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var registered []nasDeviceResponse
+	if err := json.Unmarshal(responseBody, &registered); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var network *net.IPNet
+	if ipRange != "" {
+		if _, network, err = net.ParseCIDR(ipRange); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	devices := make([]map[string]interface{}, 0)
+	ids := make([]string, 0)
+	for _, device := range registered {
+		if vendor != "" && !strings.EqualFold(device.Vendor, vendor) {
+			continue
+		}
+		if network != nil {
+			ip := net.ParseIP(device.IpAddress)
+			if ip == nil || !network.Contains(ip) {
+				continue
+			}
+		}
+
+		devices = append(devices, map[string]interface{}{
+			"id":                   device.Id,
+			"name":                 device.Name,
+			"ip_address":           device.IpAddress,
+			"vendor":               device.Vendor,
+			"description":          device.Description,
+			"site":                 device.Site,
+			"shared_secret_source": device.SharedSecretSource,
+			"shared_secret_id":     device.SharedSecretId,
+		})
+		ids = append(ids, device.Id)
+	}
+
+	d.SetId(strings.Join([]string{site, vendor, ipRange}, ","))
+	if err := d.Set("devices", devices); err != nil {
+		return diag.Errorf("error setting devices: %s", err)
+	}
+	d.Set("ids", ids)
+
+	return nil
+}
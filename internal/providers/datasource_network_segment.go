@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceNetworkSegment looks up a network segment by name or ID
+func DataSourceNetworkSegment() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkSegmentRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "segment_id"},
+				Description:  "The name of the network segment to look up.",
+			},
+			"segment_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the network segment to look up.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A description of the network segment.",
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The subnets of the segment in CIDR notation.",
+			},
+			"vlan": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VLAN ID or name associated with the segment.",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The site or location the segment belongs to.",
+			},
+		},
+	}
+}
+
+func dataSourceNetworkSegmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/network-segments")
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var segments []networkSegmentResponse
+	if err := json.Unmarshal(responseBody, &segments); err != nil {
+		return diag.FromErr(err)
+	}
+
+	name, byName := d.GetOk("name")
+	segmentID := d.Get("segment_id").(string)
+
+	for _, segment := range segments {
+		if byName && !strings.EqualFold(segment.Name, name.(string)) {
+			continue
+		}
+		if !byName && segment.Id != segmentID {
+			continue
+		}
+
+		d.SetId(segment.Id)
+		d.Set("name", segment.Name)
+		d.Set("segment_id", segment.Id)
+		d.Set("description", segment.Description)
+		d.Set("subnets", segment.Subnets)
+		d.Set("vlan", segment.Vlan)
+		d.Set("location", segment.Location)
+		return nil
+	}
+
+	if byName {
+		return diag.Errorf("no network segment named %s found", name.(string))
+	}
+	return diag.Errorf("no network segment with ID %s found", segmentID)
+}
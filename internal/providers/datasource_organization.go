@@ -0,0 +1,71 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceOrganization returns the organization the provider API key belongs to
+func DataSourceOrganization() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOrganizationRead,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the organization.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the organization.",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region the organization is hosted in.",
+			},
+			"enabled_features": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The features enabled for the organization, such as NAC, ZTNA, or TACACS.",
+			},
+		},
+	}
+}
+
+func dataSourceOrganizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/organization")
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var organization struct {
+		OrgId           string   `json:"OrgId"`
+		Name            string   `json:"Name"`
+		Region          string   `json:"Region"`
+		EnabledFeatures []string `json:"EnabledFeatures"`
+	}
+	if err := json.Unmarshal(responseBody, &organization); err != nil {
+		return diag.FromErr(err)
+	}
+	if organization.OrgId == "" {
+		return diag.Errorf("the API did not return an organization ID")
+	}
+
+	d.SetId(organization.OrgId)
+	d.Set("org_id", organization.OrgId)
+	d.Set("name", organization.Name)
+	d.Set("region", organization.Region)
+	d.Set("enabled_features", organization.EnabledFeatures)
+
+	return nil
+}
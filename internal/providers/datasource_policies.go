@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// policyTypes are the kinds of policy that can be assigned to groups and sites
+var policyTypes = []string{"authentication", "access", "risk"}
+
+// policyResponse is the API representation of a policy
+type policyResponse struct {
+	Id          string `json:"Id"`
+	Name        string `json:"Name"`
+	Type        string `json:"Type"`
+	Description string `json:"Description"`
+	Enabled     bool   `json:"Enabled"`
+}
+
+// readPolicies lists the policies of the organization, including the ones created in the portal
+func readPolicies(ctx context.Context, config *common.Config) ([]policyResponse, error) {
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/policies")
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []policyResponse
+	if err := json.Unmarshal(responseBody, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// DataSourcePolicies lists the policies of the organization, optionally filtered by type and name prefix
+func DataSourcePolicies() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePoliciesRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(policyTypes, false),
+				Description:  "Only return policies of this type: authentication, access, or risk.",
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return policies whose name starts with this prefix.",
+			},
+			"policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the policy.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the policy.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the policy: authentication, access, or risk.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A description of the policy.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Indicates whether the policy is enabled.",
+						},
+					},
+				},
+				Description: "The matching policies.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the matching policies.",
+			},
+		},
+	}
+}
+
+func dataSourcePoliciesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	policyType := d.Get("type").(string)
+	namePrefix := d.Get("name_prefix").(string)
+
+	policies, err := readPolicies(ctx, config)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	matches := make([]map[string]interface{}, 0)
+	ids := make([]string, 0)
+	for _, policy := range policies {
+		if policyType != "" && !strings.EqualFold(policy.Type, policyType) {
+			continue
+		}
+		if namePrefix != "" && !strings.HasPrefix(policy.Name, namePrefix) {
+			continue
+		}
+
+		matches = append(matches, map[string]interface{}{
+			"id":          policy.Id,
+			"name":        policy.Name,
+			"type":        policy.Type,
+			"description": policy.Description,
+			"enabled":     policy.Enabled,
+		})
+		ids = append(ids, policy.Id)
+	}
+
+	d.SetId(strings.Join([]string{policyType, namePrefix}, ","))
+	if err := d.Set("policies", matches); err != nil {
+		return diag.Errorf("error setting policies: %s", err)
+	}
+	d.Set("ids", ids)
+
+	return nil
+}
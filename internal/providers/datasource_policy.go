@@ -0,0 +1,97 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourcePolicy looks up a policy by name or ID, so portal-created policies can be referenced from Terraform
+func DataSourcePolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePolicyRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "policy_id"},
+				Description:  "The name of the policy to look up.",
+			},
+			"policy_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the policy to look up.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(policyTypes, false),
+				Description:  "The type of the policy: authentication, access, or risk. Set it to look up a name used by policies of several types.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A description of the policy.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the policy is enabled.",
+			},
+		},
+	}
+}
+
+func dataSourcePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	policies, err := readPolicies(ctx, config)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	name, byName := d.GetOk("name")
+	policyID := d.Get("policy_id").(string)
+	policyType := d.Get("type").(string)
+
+	var matches []policyResponse
+	for _, policy := range policies {
+		if byName && !strings.EqualFold(policy.Name, name.(string)) {
+			continue
+		}
+		if !byName && policy.Id != policyID {
+			continue
+		}
+		if policyType != "" && !strings.EqualFold(policy.Type, policyType) {
+			continue
+		}
+		matches = append(matches, policy)
+	}
+
+	switch {
+	case len(matches) > 1:
+		return diag.Errorf("%d policies named %s found, set type to select one", len(matches), name.(string))
+	case len(matches) == 0 && byName:
+		return diag.Errorf("no policy named %s found", name.(string))
+	case len(matches) == 0:
+		return diag.Errorf("no policy with ID %s found", policyID)
+	}
+
+	policy := matches[0]
+	d.SetId(policy.Id)
+	d.Set("name", policy.Name)
+	d.Set("policy_id", policy.Id)
+	d.Set("type", policy.Type)
+	d.Set("description", policy.Description)
+	d.Set("enabled", policy.Enabled)
+
+	return nil
+}
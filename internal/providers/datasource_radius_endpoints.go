@@ -0,0 +1,123 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceRadiusEndpoints() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRadiusEndpointsRead,
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return endpoints in this region. Defaults to all regions assigned to the organization.",
+			},
+			"endpoints": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The region of the RADIUS endpoint.",
+						},
+						"role": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The role of the endpoint, such as primary or secondary.",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the RADIUS endpoint.",
+						},
+						"authentication_port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The RADIUS authentication port.",
+						},
+						"accounting_port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The RADIUS accounting port.",
+						},
+						"radsec_port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The RadSec (RADIUS over TLS) port.",
+						},
+					},
+				},
+				Description: "The cloud RADIUS and RadSec endpoints assigned to the organization.",
+			},
+			"shared_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The RADIUS shared secret of the organization.",
+			},
+		},
+	}
+}
+
+func dataSourceRadiusEndpointsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/radius/endpoints")
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var response struct {
+		OrgId        string `json:"OrgId"`
+		SharedSecret string `json:"SharedSecret"`
+		Endpoints    []struct {
+			Region             string `json:"Region"`
+			Role               string `json:"Role"`
+			IpAddress          string `json:"IpAddress"`
+			AuthenticationPort int    `json:"AuthenticationPort"`
+			AccountingPort     int    `json:"AccountingPort"`
+			RadSecPort         int    `json:"RadSecPort"`
+		} `json:"Endpoints"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return diag.FromErr(err)
+	}
+
+	region := d.Get("region").(string)
+	endpoints := make([]map[string]interface{}, 0, len(response.Endpoints))
+	for _, endpoint := range response.Endpoints {
+		if region != "" && !strings.EqualFold(endpoint.Region, region) {
+			continue
+		}
+		endpoints = append(endpoints, map[string]interface{}{
+			"region":              endpoint.Region,
+			"role":                endpoint.Role,
+			"ip_address":          endpoint.IpAddress,
+			"authentication_port": endpoint.AuthenticationPort,
+			"accounting_port":     endpoint.AccountingPort,
+			"radsec_port":         endpoint.RadSecPort,
+		})
+	}
+
+	if region != "" && len(endpoints) == 0 {
+		return diag.Errorf("no RADIUS endpoints found in region %s", region)
+	}
+
+	d.SetId(response.OrgId + ":" + region)
+	if err := d.Set("endpoints", endpoints); err != nil {
+		return diag.Errorf("error setting endpoints: %s", err)
+	}
+	d.Set("shared_secret", response.SharedSecret)
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceRestRequest reads an arbitrary Portnox API endpoint that the provider does not model yet
+func DataSourceRestRequest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRestRequestRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The API path to call, relative to the provider base_url.",
+			},
+			"method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "GET",
+				ValidateFunc: validation.StringInSlice([]string{"GET", "POST"}, false),
+				Description:  "The HTTP method to use. POST is allowed for read-only search endpoints.",
+			},
+			"body": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The JSON request body.",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The raw response body, for use with jsondecode().",
+			},
+		},
+	}
+}
+
+func dataSourceRestRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	path := d.Get("path").(string)
+	method := d.Get("method").(string)
+
+	payload, err := restRequestPayload(d.Get("body").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var responseBody []byte
+	if method == "GET" {
+		responseBody, err = config.MakeCachedRequestWithRetry(ctx, path)
+	} else {
+		// POST is only meant for read-only endpoints, so it is sent even when validate_only is set
+		responseBody, err = config.MakeRequestWithRetry(common.WithoutValidateOnly(ctx), method, path, payload)
+	}
+	if err != nil {
+		return apiErrorDiagnostics(err, "path")
+	}
+
+	d.SetId(method + " " + path)
+	d.Set("response", string(responseBody))
+
+	return nil
+}
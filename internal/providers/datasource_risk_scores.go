@@ -0,0 +1,197 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// riskScoreScopes maps the scope attributes of the data source to the query parameters of the API
+var riskScoreScopes = map[string]string{
+	"mac_address": "mac",
+	"group_id":    "groupId",
+	"site_id":     "siteId",
+}
+
+// DataSourceRiskScores reads the current risk scores and compliance state of a device, or of the devices of a group
+// or site with aggregates, for conditional logic such as only trusting low-risk devices
+func DataSourceRiskScores() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRiskScoresRead,
+		Schema: map[string]*schema.Schema{
+			"mac_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"mac_address", "group_id", "site_id"},
+				ValidateFunc: validation.StringMatch(macAddressPattern, macAddressMessage),
+				Description:  "The MAC address of the device to read the risk score of.",
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the group to read the risk scores of.",
+			},
+			"site_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the site to read the risk scores of.",
+			},
+			"devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The MAC address of the device.",
+						},
+						"risk_score": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The current risk score of the device, from 0 (no risk) to 100.",
+						},
+						"risk_level": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The risk level of the device: low, medium, high, or critical.",
+						},
+						"compliant": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Indicates whether the device passes all of its posture checks.",
+						},
+						"failed_checks": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The names of the posture checks the device fails.",
+						},
+						"evaluated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the risk score was last evaluated.",
+						},
+					},
+				},
+				Description: "The risk scores of the devices in scope.",
+			},
+			"device_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of devices in scope.",
+			},
+			"max_risk_score": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The highest risk score of the devices in scope. For a single device, its risk score.",
+			},
+			"average_risk_score": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The average risk score of the devices in scope.",
+			},
+			"risk_level": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The risk level of the riskiest device in scope.",
+			},
+			"compliant": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether every device in scope is compliant.",
+			},
+			"non_compliant_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of devices in scope that fail at least one posture check.",
+			},
+		},
+	}
+}
+
+func dataSourceRiskScoresRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	query := url.Values{}
+	scopeAttribute := ""
+	for attribute, parameter := range riskScoreScopes {
+		if value := d.Get(attribute).(string); value != "" {
+			if attribute == "mac_address" {
+				value = normalizeMacAddress(value)
+			}
+			query.Set(parameter, value)
+			scopeAttribute = attribute
+		}
+	}
+	endpoint := "/api/risk-scores?" + query.Encode()
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, endpoint)
+	if err != nil {
+		return apiErrorDiagnostics(err, scopeAttribute)
+	}
+
+	var response struct {
+		Devices []struct {
+			MacAddress   string   `json:"MacAddress"`
+			RiskScore    int      `json:"RiskScore"`
+			RiskLevel    string   `json:"RiskLevel"`
+			Compliant    bool     `json:"Compliant"`
+			FailedChecks []string `json:"FailedChecks"`
+			EvaluatedAt  string   `json:"EvaluatedAt"`
+		} `json:"Devices"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return diag.FromErr(err)
+	}
+	if scopeAttribute == "mac_address" && len(response.Devices) == 0 {
+		return diag.Errorf("no risk score found for device %s", d.Get("mac_address").(string))
+	}
+
+	devices := make([]map[string]interface{}, 0, len(response.Devices))
+	maxScore, totalScore, nonCompliant := 0, 0, 0
+	riskLevel := ""
+	for _, device := range response.Devices {
+		devices = append(devices, map[string]interface{}{
+			"mac_address":   device.MacAddress,
+			"risk_score":    device.RiskScore,
+			"risk_level":    device.RiskLevel,
+			"compliant":     device.Compliant,
+			"failed_checks": device.FailedChecks,
+			"evaluated_at":  device.EvaluatedAt,
+		})
+
+		totalScore += device.RiskScore
+		if device.RiskScore > maxScore || riskLevel == "" {
+			maxScore = max(maxScore, device.RiskScore)
+			riskLevel = device.RiskLevel
+		}
+		if !device.Compliant {
+			nonCompliant++
+		}
+	}
+
+	averageScore := 0.0
+	if len(devices) > 0 {
+		averageScore = float64(totalScore) / float64(len(devices))
+	}
+
+	d.SetId(endpoint)
+	if err := d.Set("devices", devices); err != nil {
+		return diag.Errorf("error setting devices: %s", err)
+	}
+	d.Set("device_count", len(devices))
+	d.Set("max_risk_score", maxScore)
+	d.Set("average_risk_score", averageScore)
+	d.Set("risk_level", riskLevel)
+	d.Set("compliant", nonCompliant == 0)
+	d.Set("non_compliant_count", nonCompliant)
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// unmanagedDevicesPageSize is the number of devices requested per page
+const unmanagedDevicesPageSize = 500
+
+// unmanagedDeviceFilters maps the filter attributes of the data source to the query parameters of the API
+var unmanagedDeviceFilters = map[string]string{
+	"nas_id":  "nasId",
+	"site_id": "siteId",
+	"ssid":    "ssid",
+}
+
+// DataSourceUnmanagedDevices lists the devices Portnox has seen that are not whitelisted in any account, so
+// discovered devices can be authorized in code with for_each
+func DataSourceUnmanagedDevices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUnmanagedDevicesRead,
+		Schema: map[string]*schema.Schema{
+			"seen_within": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "7d",
+				ValidateFunc: validateDurationWithDays,
+				Description:  "Only return devices seen within this duration, such as 7d or 12h. Defaults to 7d.",
+			},
+			"nas_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return devices seen through this NAS device.",
+			},
+			"site_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return devices seen at this site.",
+			},
+			"ssid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return devices seen on this SSID.",
+			},
+			"vendor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return devices whose OUI belongs to this vendor. Matching is case-insensitive.",
+			},
+			"devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The MAC address of the device.",
+						},
+						"oui": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The OUI of the MAC address, as AA:BB:CC.",
+						},
+						"vendor": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The vendor the OUI is registered to, if known.",
+						},
+						"nas_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the NAS device the device was last seen through.",
+						},
+						"nas_ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address of the NAS device.",
+						},
+						"ssid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The SSID the device was last seen on, for wireless devices.",
+						},
+						"site_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the site the device was last seen at.",
+						},
+						"first_seen": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the device was first seen.",
+						},
+						"last_seen": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the device was last seen.",
+						},
+					},
+				},
+				Description: "The unmanaged devices matching the filters.",
+			},
+			"macs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The MAC addresses of the matching devices.",
+			},
+		},
+	}
+}
+
+func dataSourceUnmanagedDevicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	seenWithin := d.Get("seen_within").(string)
+	vendor := d.Get("vendor").(string)
+
+	window, err := parseDurationWithDays(seenWithin)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	query := url.Values{}
+	for attribute, parameter := range unmanagedDeviceFilters {
+		if value := d.Get(attribute).(string); value != "" {
+			query.Set(parameter, value)
+		}
+	}
+	query.Set("since", time.Now().Add(-window).UTC().Format(time.RFC3339))
+	query.Set("pageSize", strconv.Itoa(unmanagedDevicesPageSize))
+
+	devices := make([]map[string]interface{}, 0)
+	macs := make([]string, 0)
+	err = config.Paginate(ctx, "GET", "/api/devices/unmanaged?"+query.Encode(), "page", func(page int, responseBody []byte) (bool, error) {
+		var response struct {
+			Devices []struct {
+				MacAddress   string `json:"MacAddress"`
+				Vendor       string `json:"Vendor"`
+				NasId        string `json:"NasId"`
+				NasIpAddress string `json:"NasIpAddress"`
+				Ssid         string `json:"Ssid"`
+				SiteId       string `json:"SiteId"`
+				FirstSeen    string `json:"FirstSeen"`
+				LastSeen     string `json:"LastSeen"`
+			} `json:"Devices"`
+		}
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return false, err
+		}
+
+		for _, device := range response.Devices {
+			if vendor != "" && !strings.EqualFold(device.Vendor, vendor) {
+				continue
+			}
+
+			oui := ""
+			if digits, ok := macHex(device.MacAddress); ok && len(digits) == 12 {
+				oui = digits[0:2] + ":" + digits[2:4] + ":" + digits[4:6]
+			}
+
+			devices = append(devices, map[string]interface{}{
+				"mac_address":    device.MacAddress,
+				"oui":            oui,
+				"vendor":         device.Vendor,
+				"nas_id":         device.NasId,
+				"nas_ip_address": device.NasIpAddress,
+				"ssid":           device.Ssid,
+				"site_id":        device.SiteId,
+				"first_seen":     device.FirstSeen,
+				"last_seen":      device.LastSeen,
+			})
+			macs = append(macs, device.MacAddress)
+		}
+
+		return len(response.Devices) >= unmanagedDevicesPageSize, nil
+	})
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	// The since parameter changes on every read, so it is left out of the ID
+	query.Del("since")
+	query.Del("pageSize")
+	query.Set("seenWithin", seenWithin)
+	query.Set("vendor", vendor)
+	d.SetId(query.Encode())
+	if err := d.Set("devices", devices); err != nil {
+		return diag.Errorf("error setting devices: %s", err)
+	}
+	d.Set("macs", macs)
+
+	return nil
+}
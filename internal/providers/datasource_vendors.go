@@ -0,0 +1,128 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// portnoxVendor is an entry of the Portnox vendor catalog
+type portnoxVendor struct {
+	VendorName     string   `json:"VendorName"`
+	VendorPrefixes []string `json:"VendorPrefixes"`
+}
+
+// searchVendors returns the vendors of the Portnox catalog whose name contains the search string
+func searchVendors(ctx context.Context, config *common.Config, search string) ([]portnoxVendor, error) {
+	query := url.Values{}
+	if search != "" {
+		query.Set("search", search)
+	}
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/vendors?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Vendors []portnoxVendor `json:"Vendors"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, err
+	}
+	return response.Vendors, nil
+}
+
+// DataSourceVendors searches the Portnox vendor catalog, for resolving vendors_whitelist names and OUI prefixes
+func DataSourceVendors() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVendorsRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return the vendors whose name contains this string, ignoring case. Returns the whole catalog when unset.",
+			},
+			"exact": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return the vendor whose name equals name, ignoring case.",
+			},
+			"vendors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vendor_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the vendor, as accepted by vendors_whitelist.",
+						},
+						"vendor_prefixes": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "List of MAC address prefixes associated with this vendor.",
+						},
+					},
+				},
+				Description: "The matching vendors with their MAC address prefixes.",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the matching vendors.",
+			},
+		},
+	}
+}
+
+func dataSourceVendorsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	name := d.Get("name").(string)
+	exact := d.Get("exact").(bool)
+
+	catalog, err := searchVendors(ctx, config, name)
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	vendors := make([]map[string]interface{}, 0, len(catalog))
+	names := make([]string, 0, len(catalog))
+	for _, vendor := range catalog {
+		// Filter locally as well, in case the API ignores the search parameter
+		if exact && !strings.EqualFold(vendor.VendorName, name) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(vendor.VendorName), strings.ToLower(name)) {
+			continue
+		}
+
+		prefixes := vendor.VendorPrefixes
+		if prefixes == nil {
+			prefixes = []string{}
+		}
+		vendors = append(vendors, map[string]interface{}{
+			"vendor_name":     vendor.VendorName,
+			"vendor_prefixes": prefixes,
+		})
+		names = append(names, vendor.VendorName)
+	}
+
+	d.SetId("vendors:" + name)
+	if err := d.Set("vendors", vendors); err != nil {
+		return diag.Errorf("error setting vendors: %s", err)
+	}
+	d.Set("names", names)
+
+	return nil
+}
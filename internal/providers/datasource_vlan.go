@@ -0,0 +1,81 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceVlan() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVlanRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "vlan_id"},
+				Description:  "The symbolic name of the VLAN to look up.",
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The VLAN number to look up.",
+			},
+			"purpose": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The purpose of the VLAN.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A description of the VLAN.",
+			},
+		},
+	}
+}
+
+func dataSourceVlanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/vlans")
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var vlans []vlanResponse
+	if err := json.Unmarshal(responseBody, &vlans); err != nil {
+		return diag.FromErr(err)
+	}
+
+	name, byName := d.GetOk("name")
+	vlanID := d.Get("vlan_id").(int)
+
+	for _, vlan := range vlans {
+		if byName && !strings.EqualFold(vlan.Name, name.(string)) {
+			continue
+		}
+		if !byName && vlan.VlanId != vlanID {
+			continue
+		}
+
+		d.SetId(vlan.Id)
+		d.Set("name", vlan.Name)
+		d.Set("vlan_id", vlan.VlanId)
+		d.Set("purpose", vlan.Purpose)
+		d.Set("description", vlan.Description)
+		return nil
+	}
+
+	if byName {
+		return diag.Errorf("no VLAN named %s found", name.(string))
+	}
+	return diag.Errorf("no VLAN with ID %d found", vlanID)
+}
@@ -0,0 +1,127 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceWhoami returns the identity, scopes, and permissions of the provider API key, so configurations can check
+// with preconditions that the credential is allowed to make their changes before any change is attempted
+func DataSourceWhoami() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWhoamiRead,
+		Schema: map[string]*schema.Schema{
+			"required_permissions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Permissions to check the API key for, such as mac_accounts:write. The ones it lacks are listed in missing_permissions.",
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the API key.",
+			},
+			"identity": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the API key, or the email address of the administrator it was issued to.",
+			},
+			"identity_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of identity the API key belongs to, such as api_key or admin.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the organization the API key belongs to.",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The role assigned to the API key, such as Administrator or Read Only.",
+			},
+			"scopes": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The scopes the API key is restricted to, such as sites or account groups. Empty when the key is not restricted.",
+			},
+			"permissions": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The permissions granted to the API key, such as mac_accounts:read or mac_accounts:write.",
+			},
+			"missing_permissions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The required_permissions the API key is not granted, sorted.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the API key expires, or an empty string if it does not expire.",
+			},
+		},
+	}
+}
+
+func dataSourceWhoamiRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeCachedRequestWithRetry(ctx, "/api/tokens/current")
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var identity struct {
+		KeyId        string   `json:"KeyId"`
+		Identity     string   `json:"Identity"`
+		IdentityType string   `json:"IdentityType"`
+		OrgId        string   `json:"OrgId"`
+		Role         string   `json:"Role"`
+		Scopes       []string `json:"Scopes"`
+		Permissions  []string `json:"Permissions"`
+		ExpiresAt    string   `json:"ExpiresAt"`
+	}
+	if err := json.Unmarshal(responseBody, &identity); err != nil {
+		return diag.FromErr(err)
+	}
+	if identity.KeyId == "" {
+		return diag.Errorf("the API did not return an ID for the API key")
+	}
+
+	granted := make(map[string]bool, len(identity.Permissions))
+	for _, permission := range identity.Permissions {
+		granted[permission] = true
+	}
+	missing := make([]string, 0)
+	for _, permission := range expandStringList(d.Get("required_permissions").(*schema.Set).List()) {
+		if !granted[permission] {
+			missing = append(missing, permission)
+		}
+	}
+	sort.Strings(missing)
+
+	d.SetId(identity.KeyId)
+	d.Set("key_id", identity.KeyId)
+	d.Set("identity", identity.Identity)
+	d.Set("identity_type", identity.IdentityType)
+	d.Set("org_id", identity.OrgId)
+	d.Set("role", identity.Role)
+	d.Set("scopes", identity.Scopes)
+	d.Set("permissions", identity.Permissions)
+	d.Set("missing_permissions", missing)
+	d.Set("expires_at", identity.ExpiresAt)
+
+	return nil
+}
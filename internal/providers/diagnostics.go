@@ -0,0 +1,98 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// apiErrorDiagnostics converts an error from the API client into diagnostics. Known Portnox errors get an
+// actionable detail, and client errors are attached to the given attribute so Terraform points at the offending value.
+func apiErrorDiagnostics(err error, attribute string) diag.Diagnostics {
+	var circuitErr *common.CircuitOpenError
+	if errors.As(err, &circuitErr) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Portnox API unavailable",
+			Detail:   circuitErr.Error() + ". The remaining operations were not attempted. Retry the apply once the API has recovered.",
+		}}
+	}
+
+	var validateErr *common.ValidateOnlyError
+	if errors.As(err, &validateErr) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  validateOnlySummary,
+			Detail:   validateErr.Error() + ". The state records the planned result; unset validate_only on the provider to apply the change.",
+		}}
+	}
+
+	var itemErr *common.WhitelistItemError
+	if errors.As(err, &itemErr) {
+		return whitelistFailureDiagnostics(map[string]string{itemErr.Mac: itemErr.Reason}, attribute)
+	}
+
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return diag.FromErr(err)
+	}
+
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+		Detail:   apiErr.Hint(),
+	}
+
+	if attribute != "" && (apiErr.IsValidationError() || apiErr.IsNotFound()) {
+		diagnostic.AttributePath = cty.GetAttrPath(attribute)
+	}
+
+	return diag.Diagnostics{diagnostic}
+}
+
+// whitelistFailureDiagnostics returns one error per MAC address the API rejected from a whitelist-add batch,
+// naming the MAC address and the reason
+func whitelistFailureDiagnostics(failures map[string]string, attribute string) diag.Diagnostics {
+	macs := make([]string, 0, len(failures))
+	for mac := range failures {
+		macs = append(macs, mac)
+	}
+	sort.Strings(macs)
+
+	var diags diag.Diagnostics
+	for _, mac := range macs {
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("MAC address %s was not added to the whitelist", mac),
+			Detail:   failures[mac],
+		}
+		if attribute != "" {
+			diagnostic.AttributePath = cty.GetAttrPath(attribute)
+		}
+		diags = append(diags, diagnostic)
+	}
+	return diags
+}
+
+// removeFromState clears a resource that no longer exists in Portnox from state and returns a warning,
+// so the next plan proposes to recreate it instead of failing the refresh
+func removeFromState(d *schema.ResourceData, resourceType string, reason string) diag.Diagnostics {
+	id := d.Id()
+	log.Printf("[WARN] %s: %s, removing %s from state", resourceType, reason, id)
+	d.SetId("")
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Resource not found",
+			Detail:   fmt.Sprintf("%s %s is missing from the API (%s) and will be recreated on the next apply.", resourceType, id, reason),
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package providers
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// EphemeralResource is a resource whose values only exist for the duration of a Terraform run and are never stored
+// in the plan or state. The plugin SDK cannot express ephemeral resources, so they are implemented against the plugin
+// protocol and served next to the SDK resources by the provider server.
+type EphemeralResource struct {
+	Schema *tfprotov5.Schema
+
+	// Open creates the ephemeral object from the configured values. It returns the values of all attributes, and
+	// private data that is passed to Close.
+	Open func(ctx context.Context, config *common.Config, values map[string]tftypes.Value) (map[string]tftypes.Value, []byte, error)
+
+	// Close releases the ephemeral object once Terraform no longer needs it
+	Close func(ctx context.Context, config *common.Config, private []byte) error
+}
+
+// ephemeralString returns the value of a string attribute, or an empty string when it is null or unknown
+func ephemeralString(values map[string]tftypes.Value, name string) (string, error) {
+	v, ok := values[name]
+	if !ok || v.IsNull() || !v.IsKnown() {
+		return "", nil
+	}
+	var value string
+	if err := v.As(&value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// ephemeralInt returns the value of a number attribute, or fallback when it is null or unknown
+func ephemeralInt(values map[string]tftypes.Value, name string, fallback int) (int, error) {
+	v, ok := values[name]
+	if !ok || v.IsNull() || !v.IsKnown() {
+		return fallback, nil
+	}
+	var value big.Float
+	if err := v.As(&value); err != nil {
+		return 0, err
+	}
+	result, _ := value.Int64()
+	return int(result), nil
+}
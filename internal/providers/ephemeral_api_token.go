@@ -0,0 +1,129 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	apiTokenDefaultTTL = 900
+	apiTokenMinTTL     = 60
+	apiTokenMaxTTL     = 86400
+)
+
+// EphemeralApiToken issues a short-lived Portnox API token for the duration of a Terraform run, for use by other
+// providers or provisioners in the same configuration. The token is revoked when Terraform closes the resource.
+func EphemeralApiToken() *EphemeralResource {
+	return &EphemeralResource{
+		Schema: &tfprotov5.Schema{
+			Block: &tfprotov5.SchemaBlock{
+				Description: "Issues a short-lived Portnox API token that is never stored in the plan or state, and revokes it at the end of the run.",
+				Attributes: []*tfprotov5.SchemaAttribute{
+					{
+						Name:        "ttl_seconds",
+						Type:        tftypes.Number,
+						Optional:    true,
+						Description: fmt.Sprintf("The lifetime of the token in seconds, between %d and %d. Defaults to %d.", apiTokenMinTTL, apiTokenMaxTTL, apiTokenDefaultTTL),
+					},
+					{
+						Name:        "description",
+						Type:        tftypes.String,
+						Optional:    true,
+						Description: "A description of the token, shown in the Portnox audit log.",
+					},
+					{
+						Name:        "token_id",
+						Type:        tftypes.String,
+						Computed:    true,
+						Description: "The ID of the token.",
+					},
+					{
+						Name:        "token",
+						Type:        tftypes.String,
+						Computed:    true,
+						Sensitive:   true,
+						Description: "The API token.",
+					},
+					{
+						Name:        "expires_at",
+						Type:        tftypes.String,
+						Computed:    true,
+						Description: "The date and time the token expires.",
+					},
+				},
+			},
+		},
+		Open:  ephemeralApiTokenOpen,
+		Close: ephemeralApiTokenClose,
+	}
+}
+
+func ephemeralApiTokenOpen(ctx context.Context, config *common.Config, values map[string]tftypes.Value) (map[string]tftypes.Value, []byte, error) {
+	ttl, err := ephemeralInt(values, "ttl_seconds", apiTokenDefaultTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ttl < apiTokenMinTTL || ttl > apiTokenMaxTTL {
+		return nil, nil, fmt.Errorf("ttl_seconds must be between %d and %d, got %d", apiTokenMinTTL, apiTokenMaxTTL, ttl)
+	}
+	description, err := ephemeralString(values, "description")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload := map[string]interface{}{
+		"TtlSeconds":  ttl,
+		"Description": description,
+	}
+
+	// The token is used by the rest of the run, so it is issued even when validate_only is set
+	responseBody, err := config.MakeRequestWithRetry(common.WithoutValidateOnly(ctx), "POST", "/api/tokens", payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var token struct {
+		Id        string `json:"Id"`
+		Token     string `json:"Token"`
+		ExpiresAt string `json:"ExpiresAt"`
+	}
+	if err := json.Unmarshal(responseBody, &token); err != nil {
+		return nil, nil, fmt.Errorf("error parsing token response: %s", err)
+	}
+	if token.Token == "" {
+		return nil, nil, fmt.Errorf("the API did not return a token")
+	}
+
+	result := map[string]tftypes.Value{
+		"ttl_seconds": tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(int64(ttl))),
+		"description": values["description"],
+		"token_id":    tftypes.NewValue(tftypes.String, token.Id),
+		"token":       tftypes.NewValue(tftypes.String, token.Token),
+		"expires_at":  tftypes.NewValue(tftypes.String, token.ExpiresAt),
+	}
+
+	return result, []byte(token.Id), nil
+}
+
+func ephemeralApiTokenClose(ctx context.Context, config *common.Config, private []byte) error {
+	tokenID := string(private)
+	if tokenID == "" {
+		return nil
+	}
+
+	// Revoke the token so it cannot be used after the run, even before it expires
+	if _, err := config.MakeRequestWithRetry(common.WithoutValidateOnly(ctx), "DELETE", "/api/tokens/"+tokenID, nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
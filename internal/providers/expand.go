@@ -0,0 +1,12 @@
+package providers
+
+// expandStringList converts a Terraform list of strings into a string slice for API payloads
+func expandStringList(list []interface{}) []string {
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if value, ok := item.(string); ok {
+			result = append(result, value)
+		}
+	}
+	return result
+}
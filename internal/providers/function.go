@@ -0,0 +1,26 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Function is a provider-defined function, called in expressions as provider::portnox::<name>. The plugin SDK cannot
+// express functions, so they are implemented against the plugin protocol and served next to the SDK resources by the
+// provider server. Functions never call the API and can be used before the provider is configured.
+type Function struct {
+	Definition *tfprotov5.Function
+
+	// Call computes the result of the function from its arguments, which are decoded to the parameter types
+	Call func(ctx context.Context, arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError)
+}
+
+// functionArgumentError returns a function error attributed to the argument at the given position
+func functionArgumentError(position int64, text string) *tfprotov5.FunctionError {
+	return &tfprotov5.FunctionError{
+		Text:             text,
+		FunctionArgument: &position,
+	}
+}
@@ -0,0 +1,70 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// FunctionMacInOui tests a MAC address against a list of OUI/prefixes, for use in conditional expressions such as
+// for expression filters, where a data source per device would be cumbersome
+func FunctionMacInOui() *Function {
+	return &Function{
+		Definition: &tfprotov5.Function{
+			Summary:     "Test whether a MAC address falls within a list of OUI prefixes",
+			Description: "Returns true when the MAC address starts with any of the given OUIs or MAC prefixes. MAC addresses and prefixes may be written in colon, dash, dotted, or bare notation, in either letter case.",
+			Parameters: []*tfprotov5.FunctionParameter{
+				{
+					Name:        "mac",
+					Type:        tftypes.String,
+					Description: "The MAC address to test.",
+				},
+				{
+					Name:        "prefixes",
+					Type:        tftypes.List{ElementType: tftypes.String},
+					Description: "The OUIs or MAC prefixes (e.g. AA:BB:CC or AABBCC) to test the MAC address against.",
+				},
+			},
+			Return: &tfprotov5.FunctionReturn{
+				Type: tftypes.Bool,
+			},
+		},
+		Call: callMacInOui,
+	}
+}
+
+func callMacInOui(ctx context.Context, arguments []tftypes.Value) (tftypes.Value, *tfprotov5.FunctionError) {
+	var macAddress string
+	if err := arguments[0].As(&macAddress); err != nil {
+		return tftypes.Value{}, functionArgumentError(0, err.Error())
+	}
+	macDigits, ok := macHex(macAddress)
+	if !ok || len(macDigits) != 12 {
+		return tftypes.Value{}, functionArgumentError(0, fmt.Sprintf("%q is not a valid MAC address", macAddress))
+	}
+
+	var prefixValues []tftypes.Value
+	if err := arguments[1].As(&prefixValues); err != nil {
+		return tftypes.Value{}, functionArgumentError(1, err.Error())
+	}
+	prefixes := make([]string, 0, len(prefixValues))
+	for _, value := range prefixValues {
+		if value.IsNull() {
+			return tftypes.Value{}, functionArgumentError(1, "prefixes must not contain null values")
+		}
+		var prefix string
+		if err := value.As(&prefix); err != nil {
+			return tftypes.Value{}, functionArgumentError(1, err.Error())
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	matchedPrefix, err := matchOuiPrefix(macAddress, prefixes)
+	if err != nil {
+		return tftypes.Value{}, functionArgumentError(1, err.Error())
+	}
+
+	return tftypes.NewValue(tftypes.Bool, matchedPrefix != ""), nil
+}
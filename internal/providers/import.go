@@ -0,0 +1,51 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importIDSeparators are the characters that cannot appear in the ID of a single object, as they separate the parts
+// of composite import IDs or of an API path
+const importIDSeparators = ",;/?#"
+
+// importStateID returns an importer for resources identified by the ID of a single API object, described by format
+// in errors. A malformed ID, such as an empty one or a composite ID meant for another resource, fails with the
+// expected format instead of a not-found error from the first read.
+func importStateID(format string, resource func() *schema.Resource) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		id := d.Id()
+		if strings.TrimSpace(id) == "" || strings.TrimSpace(id) != id || strings.ContainsAny(id, importIDSeparators) {
+			return nil, fmt.Errorf("invalid import ID %q, expected %s", id, format)
+		}
+
+		setSchemaDefaults(d, resource().Schema)
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// importStateFixedID returns an importer for organization-wide singleton resources, which only accept their fixed ID
+func importStateFixedID(fixedID string, resource func() *schema.Resource) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if d.Id() != fixedID {
+			return nil, fmt.Errorf("invalid import ID %q, expected %s: the organization has exactly one", d.Id(), fixedID)
+		}
+
+		setSchemaDefaults(d, resource().Schema)
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// setSchemaDefaults sets the arguments that have a default to it. An imported object only has the attributes its read
+// sets, so without this, arguments the API does not return would show a change from null to their default after
+// import, and -generate-config-out would leave them out.
+func setSchemaDefaults(d *schema.ResourceData, attributes map[string]*schema.Schema) {
+	for name, attribute := range attributes {
+		if attribute.Default != nil {
+			d.Set(name, attribute.Default)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// macSeparators matches the separator characters allowed in MAC address and OUI prefix notation
+var macSeparators = regexp.MustCompile(`[:\-.]`)
+
+// macAddressPattern matches a MAC address in colon or dash notation, Cisco dotted notation (aabb.ccdd.eeff), or as
+// 12 bare hex digits
+var macAddressPattern = regexp.MustCompile(`^(([0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}|[0-9A-Fa-f]{4}\.[0-9A-Fa-f]{4}\.[0-9A-Fa-f]{4}|[0-9A-Fa-f]{12})$`)
+
+// macAddressMessage is the validation error for a value that does not match macAddressPattern
+const macAddressMessage = "must be a valid MAC address in colon, dash, dotted, or bare notation (e.g., 00:00:00:00:00:00 or 0000.0000.0000)"
+
+// macDescriptionPattern matches the characters the API accepts in a whitelist entry description: letters, digits,
+// spaces, dots, underscores, and dashes. Leading and trailing spaces are rejected, as the API trims them.
+var macDescriptionPattern = regexp.MustCompile(`^([a-zA-Z0-9._-]([a-zA-Z0-9 ._-]*[a-zA-Z0-9._-])?)?$`)
+
+// macDescriptionMessage is the validation error for a description that does not match macDescriptionPattern
+const macDescriptionMessage = "description must contain only letters, digits, spaces, dots, underscores, or dashes, without leading or trailing spaces, and be up to 64 characters long"
+
+// macHexPattern matches a string of hexadecimal digits only
+var macHexPattern = regexp.MustCompile(`^[0-9A-F]*$`)
+
+// macHex strips separators from a MAC address or prefix and returns its upper-case hex digits.
+// The second return value is false if the input contains anything other than hex digits and separators.
+func macHex(value string) (string, bool) {
+	hex := strings.ToUpper(macSeparators.ReplaceAllString(strings.TrimSpace(value), ""))
+	if hex == "" || !macHexPattern.MatchString(hex) {
+		return "", false
+	}
+	return hex, true
+}
+
+// normalizeMacAddress returns a MAC address in the canonical upper-case colon notation, e.g. AA:BB:CC:DD:EE:FF, which
+// is the notation sent to the API. Values that are not a MAC address are returned unchanged.
+func normalizeMacAddress(value string) string {
+	if !macAddressPattern.MatchString(strings.TrimSpace(value)) {
+		return value
+	}
+	digits, _ := macHex(value)
+	return formatMacAddress(digits, "colon", false)
+}
+
+// macAddressFormats are the notations formatMacAddress writes a MAC address in
+var macAddressFormats = []string{"colon", "dash", "dotted", "bare"}
+
+// formatMacAddress writes the 12 hex digits of a MAC address in one of macAddressFormats, e.g. AA:BB:CC:DD:EE:FF,
+// AA-BB-CC-DD-EE-FF, AABB.CCDD.EEFF, or AABBCCDDEEFF, in upper case or, if lower is set, in lower case
+func formatMacAddress(digits, format string, lower bool) string {
+	if lower {
+		digits = strings.ToLower(digits)
+	}
+	switch format {
+	case "dotted":
+		return digits[0:4] + "." + digits[4:8] + "." + digits[8:12]
+	case "bare":
+		return digits
+	}
+	separator := ":"
+	if format == "dash" {
+		separator = "-"
+	}
+	pairs := make([]string, 0, 6)
+	for i := 0; i < len(digits); i += 2 {
+		pairs = append(pairs, digits[i:i+2])
+	}
+	return strings.Join(pairs, separator)
+}
+
+// normalizeMacAddressState stores MAC address attributes in the canonical notation
+func normalizeMacAddressState(v interface{}) string {
+	return normalizeMacAddress(v.(string))
+}
+
+// suppressEquivalentMacAddress suppresses the diff between two notations of the same MAC address, such as a state
+// written before MAC addresses were normalized and a configuration in another notation
+func suppressEquivalentMacAddress(k, old, new string, d *schema.ResourceData) bool {
+	oldDigits, ok := macHex(old)
+	if !ok {
+		return false
+	}
+	newDigits, ok := macHex(new)
+	return ok && oldDigits == newDigits
+}
+
+// macMatchesPrefix reports whether the MAC address starts with the given OUI or prefix,
+// regardless of the separator style or letter case used by either value
+func macMatchesPrefix(macAddress, prefix string) bool {
+	macDigits, ok := macHex(macAddress)
+	if !ok || len(macDigits) != 12 {
+		return false
+	}
+
+	prefixDigits, ok := macHex(prefix)
+	if !ok || len(prefixDigits) > 12 {
+		return false
+	}
+
+	return strings.HasPrefix(macDigits, prefixDigits)
+}
+
+// accountMacWhiteList returns the whitelist entries of an account. Both whitelist formats are decoded by
+// common.MacWhiteList; a response in a format other than the one detected when the provider was configured is
+// still read, with a warning.
+func accountMacWhiteList(config *common.Config, account common.MacAccount) []map[string]interface{} {
+	if account.AgentlessOptions == nil {
+		return []map[string]interface{}{}
+	}
+	whitelist := account.AgentlessOptions.MacWhiteList
+
+	format := config.WhitelistFormat()
+	if format != common.WhitelistFormatUnknown && whitelist.Format != common.WhitelistFormatUnknown && whitelist.Format != format {
+		log.Printf("[WARN] Account %s returned a MAC whitelist that does not match the detected %s format", account.AccountName, format)
+	}
+
+	if whitelist.Entries == nil {
+		return []map[string]interface{}{}
+	}
+	return whitelist.Entries
+}
+
+// macAccountName returns the name of the account a whitelist resource manages. When the account is referenced by
+// account_id, the name is looked up by ID and stored in account_name, so the resource follows a renamed account.
+func macAccountName(ctx context.Context, config *common.Config, d *schema.ResourceData) (string, error) {
+	accountID := d.Get("account_id").(string)
+	if accountID == "" {
+		return d.Get("account_name").(string), nil
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+accountID, nil)
+	if err != nil {
+		return "", err
+	}
+	var account common.MacAccount
+	if err := json.Unmarshal(responseBody, &account); err != nil {
+		return "", err
+	}
+	if account.AccountName == "" {
+		return "", fmt.Errorf("the API did not return a name for account %s", accountID)
+	}
+
+	d.Set("account_name", account.AccountName)
+	return account.AccountName, nil
+}
+
+// customizeDiffAccountName updates a whitelist resource in place when its account_name changes because the account
+// is renamed, so its entries stay in the whitelist and their devices authorized. When the new name is an account
+// that already exists, the entries move to another account and the resource is replaced.
+func customizeDiffAccountName(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("account_name") {
+		return nil
+	}
+	if !d.NewValueKnown("account_name") {
+		return d.ForceNew("account_name")
+	}
+	config := m.(*common.Config)
+
+	_, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+d.Get("account_name").(string), nil)
+	if err == nil {
+		return d.ForceNew("account_name")
+	}
+	if !config.IsNotFoundError(err) {
+		return fmt.Errorf("error looking up account %s: %s", d.Get("account_name").(string), err)
+	}
+	return nil
+}
+
+// checkAccountRenamed verifies, when account_name changed, that the previous account no longer exists because it
+// was renamed. Otherwise the change names another account, which was planned in place because it did not exist yet.
+func checkAccountRenamed(ctx context.Context, config *common.Config, d *schema.ResourceData) error {
+	if !d.HasChange("account_name") || d.Get("account_id").(string) != "" {
+		return nil
+	}
+	oldName, newName := d.GetChange("account_name")
+
+	_, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+oldName.(string), nil)
+	if err == nil {
+		return fmt.Errorf("account_name changed from %s to %s, but account %s still exists, so it was not renamed; replace the resource, e.g. with terraform apply -replace, to move its entries to %s", oldName, newName, oldName, newName)
+	}
+	if !config.IsNotFoundError(err) {
+		return err
+	}
+	return nil
+}
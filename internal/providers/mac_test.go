@@ -0,0 +1,76 @@
+package providers
+
+import "testing"
+
+func TestMacHex(t *testing.T) {
+	cases := []struct {
+		value  string
+		want   string
+		wantOk bool
+	}{
+		{"AA:BB:CC:DD:EE:FF", "AABBCCDDEEFF", true},
+		{"aa-bb-cc-dd-ee-ff", "AABBCCDDEEFF", true},
+		{"aabb.ccdd.eeff", "AABBCCDDEEFF", true},
+		{"AaBbCcDdEeFf", "AABBCCDDEEFF", true},
+		{" 00:11:22:33:44:55 ", "001122334455", true},
+		{"00:11:22", "001122", true},
+		{"", "", false},
+		{"zz:bb:cc:dd:ee:ff", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, ok := macHex(tc.value)
+			if got != tc.want || ok != tc.wantOk {
+				t.Errorf("macHex(%q) = %q, %t, want %q, %t", tc.value, got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestNormalizeMacAddress(t *testing.T) {
+	cases := []struct {
+		value string
+		want  string
+	}{
+		{"AA:BB:CC:DD:EE:FF", "AA:BB:CC:DD:EE:FF"},
+		{"aa:bb:cc:dd:ee:ff", "AA:BB:CC:DD:EE:FF"},
+		{"aa-bb-cc-dd-ee-ff", "AA:BB:CC:DD:EE:FF"},
+		{"aabb.ccdd.eeff", "AA:BB:CC:DD:EE:FF"},
+		{"AABBCCDDEEFF", "AA:BB:CC:DD:EE:FF"},
+		{"aAbBcCdDeEfF", "AA:BB:CC:DD:EE:FF"},
+		// Values that are not a MAC address are left for validation to reject
+		{"AA:BB:CC", "AA:BB:CC"},
+		{"not-a-mac", "not-a-mac"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			if got := normalizeMacAddress(tc.value); got != tc.want {
+				t.Errorf("normalizeMacAddress(%q) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatMacAddress(t *testing.T) {
+	cases := []struct {
+		format string
+		lower  bool
+		want   string
+	}{
+		{"colon", false, "AA:BB:CC:DD:EE:0F"},
+		{"dash", false, "AA-BB-CC-DD-EE-0F"},
+		{"dotted", false, "AABB.CCDD.EE0F"},
+		{"bare", false, "AABBCCDDEE0F"},
+		{"colon", true, "aa:bb:cc:dd:ee:0f"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := formatMacAddress("AABBCCDDEE0F", tc.format, tc.lower); got != tc.want {
+				t.Errorf("formatMacAddress(%q, %t) = %q, want %q", tc.format, tc.lower, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceAccessSchedule manages an access schedule: the days and times of day during which network access is
+// allowed, such as weekdays 08:00 to 18:00 for contractors. Policy assignments reference a schedule to be enforced
+// only within it.
+func ResourceAccessSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccessScheduleCreate,
+		ReadContext:   resourceAccessScheduleRead,
+		UpdateContext: resourceAccessScheduleUpdate,
+		DeleteContext: resourceAccessScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the access schedule", ResourceAccessSchedule),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the access schedule.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the access schedule.",
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "UTC",
+				Description: "The IANA time zone of the start and end times of the windows.",
+			},
+			"window": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(daysOfWeek, false),
+							},
+							Description: "The days of the week the window applies to.",
+						},
+						"start_time": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(timeOfDayPattern, "must be a time of day in HH:MM format"),
+							Description:  "The time of day access starts, in HH:MM format.",
+						},
+						"end_time": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(timeOfDayPattern, "must be a time of day in HH:MM format"),
+							Description:  "The time of day access ends, in HH:MM format. A time before start_time ends the window on the next day.",
+						},
+					},
+				},
+				Description: "The windows during which access is allowed. Access is allowed when any window applies.",
+			},
+		},
+	}
+}
+
+// accessSchedulePayload builds the API representation of the access schedule from the resource data
+func accessSchedulePayload(d *schema.ResourceData) (map[string]interface{}, diag.Diagnostics) {
+	windows := make([]map[string]interface{}, 0)
+	for i, item := range d.Get("window").([]interface{}) {
+		window := item.(map[string]interface{})
+		if window["start_time"].(string) == window["end_time"].(string) {
+			return nil, diag.Errorf("window %d: end_time must differ from start_time", i+1)
+		}
+		windows = append(windows, map[string]interface{}{
+			"Days":      expandStringList(window["days"].(*schema.Set).List()),
+			"StartTime": window["start_time"].(string),
+			"EndTime":   window["end_time"].(string),
+		})
+	}
+
+	return map[string]interface{}{
+		"Name":        d.Get("name").(string),
+		"Description": d.Get("description").(string),
+		"Timezone":    d.Get("timezone").(string),
+		"Windows":     windows,
+	}, nil
+}
+
+func resourceAccessScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := accessSchedulePayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/access-schedules", payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var schedule struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &schedule); err != nil {
+		return diag.FromErr(err)
+	}
+	if schedule.Id == "" {
+		return diag.Errorf("the API did not return an ID for access schedule %s", d.Get("name").(string))
+	}
+
+	d.SetId(schedule.Id)
+
+	return resourceAccessScheduleRead(ctx, d, m)
+}
+
+func resourceAccessScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/access-schedules/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_access_schedule", "access schedule not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var schedule struct {
+		Name        string `json:"Name"`
+		Description string `json:"Description"`
+		Timezone    string `json:"Timezone"`
+		Windows     []struct {
+			Days      []string `json:"Days"`
+			StartTime string   `json:"StartTime"`
+			EndTime   string   `json:"EndTime"`
+		} `json:"Windows"`
+	}
+	if err := json.Unmarshal(responseBody, &schedule); err != nil {
+		return diag.FromErr(err)
+	}
+
+	windows := make([]map[string]interface{}, 0, len(schedule.Windows))
+	for _, window := range schedule.Windows {
+		windows = append(windows, map[string]interface{}{
+			"days":       window.Days,
+			"start_time": window.StartTime,
+			"end_time":   window.EndTime,
+		})
+	}
+
+	d.Set("name", schedule.Name)
+	d.Set("description", schedule.Description)
+	d.Set("timezone", schedule.Timezone)
+	if err := d.Set("window", windows); err != nil {
+		return diag.Errorf("error setting window: %s", err)
+	}
+
+	return nil
+}
+
+func resourceAccessScheduleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := accessSchedulePayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/access-schedules/"+d.Id(), payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceAccessScheduleRead(ctx, d, m)
+}
+
+func resourceAccessScheduleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/access-schedules/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceAccountExpirationPolicy manages the default expiration the API applies to whitelist entries of a MAC-based
+// account that are added without one, so per-account TTLs live in one place instead of on every entry
+func ResourceAccountExpirationPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccountExpirationPolicyCreate,
+		ReadContext:   resourceAccountExpirationPolicyRead,
+		UpdateContext: resourceAccountExpirationPolicyUpdate,
+		DeleteContext: resourceAccountExpirationPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the name of the MAC-based account", ResourceAccountExpirationPolicy),
+		},
+		CustomizeDiff: resourceAccountExpirationPolicyCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"account_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the MAC-based account the policy applies to.",
+			},
+			"default_expiration_days": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 3650),
+				Description:  "The number of days after which whitelist entries added without an expiration expire.",
+			},
+			"max_expiration_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 3650),
+				Description:  "The maximum number of days ahead an entry may expire. Entries with a later expiration are rejected by the API. 0 means no maximum.",
+			},
+			"apply_to_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also sets the default expiration on the existing entries without an expiration when the policy is created or changed.",
+			},
+		},
+	}
+}
+
+func resourceAccountExpirationPolicyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	defaultDays := d.Get("default_expiration_days").(int)
+	maxDays := d.Get("max_expiration_days").(int)
+	if maxDays > 0 && defaultDays > maxDays {
+		return fmt.Errorf("default_expiration_days (%d) must not be greater than max_expiration_days (%d)", defaultDays, maxDays)
+	}
+	return nil
+}
+
+// accountExpirationPolicyEndpoint returns the API path of the expiration policy of an account
+func accountExpirationPolicyEndpoint(accountName string) string {
+	return "/api/mac-based-accounts/" + accountName + "/expiration-policy"
+}
+
+// accountExpirationPolicyPayload builds the API representation of the expiration policy from the resource data
+func accountExpirationPolicyPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"DefaultExpirationDays": d.Get("default_expiration_days").(int),
+		"MaxExpirationDays":     d.Get("max_expiration_days").(int),
+		"ApplyToExisting":       d.Get("apply_to_existing").(bool),
+	}
+}
+
+func resourceAccountExpirationPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	accountName := d.Get("account_name").(string)
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", accountExpirationPolicyEndpoint(accountName), accountExpirationPolicyPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "account_name")
+	}
+
+	d.SetId(accountName)
+
+	return resourceAccountExpirationPolicyRead(ctx, d, m)
+}
+
+func resourceAccountExpirationPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", accountExpirationPolicyEndpoint(d.Id()), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_account_expiration_policy", "expiration policy not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var policy struct {
+		DefaultExpirationDays int `json:"DefaultExpirationDays"`
+		MaxExpirationDays     int `json:"MaxExpirationDays"`
+	}
+	if err := json.Unmarshal(responseBody, &policy); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// An account without a policy reports no default expiration
+	if policy.DefaultExpirationDays == 0 {
+		return removeFromState(d, "portnox_account_expiration_policy", "expiration policy not set")
+	}
+
+	d.Set("account_name", d.Id())
+	d.Set("default_expiration_days", policy.DefaultExpirationDays)
+	d.Set("max_expiration_days", policy.MaxExpirationDays)
+
+	return nil
+}
+
+func resourceAccountExpirationPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if d.HasChanges("default_expiration_days", "max_expiration_days", "apply_to_existing") {
+		if _, err := config.MakeRequestWithRetry(ctx, "PUT", accountExpirationPolicyEndpoint(d.Id()), accountExpirationPolicyPayload(d)); err != nil {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	return resourceAccountExpirationPolicyRead(ctx, d, m)
+}
+
+func resourceAccountExpirationPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	// Removing the policy leaves the expirations already set on entries in place
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", accountExpirationPolicyEndpoint(d.Id()), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceAccountNote attaches an audit note to an account or a device, such as who approved the device and the
+// ticket it was approved in, so the approval trail is kept in code next to the whitelist entry it justifies
+func ResourceAccountNote() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccountNoteCreate,
+		ReadContext:   resourceAccountNoteRead,
+		UpdateContext: resourceAccountNoteUpdate,
+		DeleteContext: resourceAccountNoteDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the note", ResourceAccountNote),
+		},
+		Schema: map[string]*schema.Schema{
+			"target_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"account", "device"}, false),
+				Description:  "The type of object the note is attached to: account or device.",
+			},
+			"target": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The name of the account, or the MAC address of the device, the note is attached to.",
+			},
+			"note": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The text of the note.",
+			},
+			"approved_by": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The person who approved the account or device.",
+			},
+			"ticket": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The reference of the ticket the account or device was approved in.",
+			},
+			"created_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The administrator or API key that created the note.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the note was created.",
+			},
+		},
+	}
+}
+
+// accountNotePayload builds the API representation of the note from the resource data
+func accountNotePayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"TargetType": d.Get("target_type").(string),
+		"Target":     accountNoteTarget(d),
+		"Text":       d.Get("note").(string),
+		"ApprovedBy": d.Get("approved_by").(string),
+		"Ticket":     d.Get("ticket").(string),
+	}
+}
+
+// accountNoteTarget returns the target of the note, with the MAC address of a device in the canonical notation
+func accountNoteTarget(d *schema.ResourceData) string {
+	target := d.Get("target").(string)
+	if d.Get("target_type").(string) == "device" {
+		return normalizeMacAddress(target)
+	}
+	return target
+}
+
+func resourceAccountNoteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if d.Get("target_type").(string) == "device" && !macAddressPattern.MatchString(d.Get("target").(string)) {
+		return diag.Errorf("target %s when target_type is device", macAddressMessage)
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/notes", accountNotePayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "target")
+	}
+
+	var note struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &note); err != nil {
+		return diag.FromErr(err)
+	}
+	if note.Id == "" {
+		return diag.Errorf("the API did not return an ID for the note on %s %s", d.Get("target_type").(string), d.Get("target").(string))
+	}
+
+	d.SetId(note.Id)
+
+	return resourceAccountNoteRead(ctx, d, m)
+}
+
+func resourceAccountNoteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/notes/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_account_note", "note not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var note struct {
+		TargetType string `json:"TargetType"`
+		Target     string `json:"Target"`
+		Text       string `json:"Text"`
+		ApprovedBy string `json:"ApprovedBy"`
+		Ticket     string `json:"Ticket"`
+		CreatedBy  string `json:"CreatedBy"`
+		CreatedAt  string `json:"CreatedAt"`
+	}
+	if err := json.Unmarshal(responseBody, &note); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("target_type", note.TargetType)
+	// Keep the notation of the configuration when the API returns the MAC address of a device in another one
+	current, _ := macHex(d.Get("target").(string))
+	if returned, _ := macHex(note.Target); note.TargetType != "device" || returned != current {
+		d.Set("target", note.Target)
+	}
+	d.Set("note", note.Text)
+	d.Set("approved_by", note.ApprovedBy)
+	d.Set("ticket", note.Ticket)
+	d.Set("created_by", note.CreatedBy)
+	d.Set("created_at", note.CreatedAt)
+
+	return nil
+}
+
+func resourceAccountNoteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/notes/"+d.Id(), accountNotePayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceAccountNoteRead(ctx, d, m)
+}
+
+func resourceAccountNoteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/notes/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
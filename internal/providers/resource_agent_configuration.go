@@ -0,0 +1,170 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceAgentConfiguration manages an AgentP agent configuration profile and exposes its enrollment key and URL
+func ResourceAgentConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAgentConfigurationCreate,
+		ReadContext:   resourceAgentConfigurationRead,
+		UpdateContext: resourceAgentConfigurationUpdate,
+		DeleteContext: resourceAgentConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the agent configuration profile", ResourceAgentConfiguration),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the agent configuration profile.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the agent configuration profile.",
+			},
+			"auto_update_channel": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "stable",
+				ValidateFunc: validation.StringInSlice([]string{"stable", "preview", "disabled"}, false),
+				Description:  "The channel agents use for automatic updates. One of stable, preview, or disabled.",
+			},
+			"enabled_features": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The agent features enabled by the profile, such as risk_assessment, remediation, or ztna.",
+			},
+			"ui_visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "visible",
+				ValidateFunc: validation.StringInSlice([]string{"visible", "minimal", "hidden"}, false),
+				Description:  "How much of the agent user interface is shown to the end user. One of visible, minimal, or hidden.",
+			},
+			"allowed_actions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The actions end users are allowed to take on the agent, such as pause, disable, or uninstall.",
+			},
+			"enrollment_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The key agents use to enroll with this profile.",
+			},
+			"enrollment_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL agents use to enroll with this profile.",
+			},
+		},
+	}
+}
+
+// agentConfigurationPayload builds the API representation of the agent configuration from the resource data
+func agentConfigurationPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"Name":              d.Get("name").(string),
+		"Description":       d.Get("description").(string),
+		"AutoUpdateChannel": d.Get("auto_update_channel").(string),
+		"EnabledFeatures":   expandStringList(d.Get("enabled_features").(*schema.Set).List()),
+		"UiVisibility":      d.Get("ui_visibility").(string),
+		"AllowedActions":    expandStringList(d.Get("allowed_actions").(*schema.Set).List()),
+	}
+}
+
+func resourceAgentConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/agent-configurations", agentConfigurationPayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var profile struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &profile); err != nil {
+		return diag.FromErr(err)
+	}
+	if profile.Id == "" {
+		return diag.Errorf("the API did not return an ID for agent configuration %s", d.Get("name").(string))
+	}
+
+	d.SetId(profile.Id)
+
+	return resourceAgentConfigurationRead(ctx, d, m)
+}
+
+func resourceAgentConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/agent-configurations/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_agent_configuration", "agent configuration not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var profile struct {
+		Name              string   `json:"Name"`
+		Description       string   `json:"Description"`
+		AutoUpdateChannel string   `json:"AutoUpdateChannel"`
+		EnabledFeatures   []string `json:"EnabledFeatures"`
+		UiVisibility      string   `json:"UiVisibility"`
+		AllowedActions    []string `json:"AllowedActions"`
+		EnrollmentKey     string   `json:"EnrollmentKey"`
+		EnrollmentUrl     string   `json:"EnrollmentUrl"`
+	}
+	if err := json.Unmarshal(responseBody, &profile); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", profile.Name)
+	d.Set("description", profile.Description)
+	d.Set("auto_update_channel", profile.AutoUpdateChannel)
+	d.Set("enabled_features", profile.EnabledFeatures)
+	d.Set("ui_visibility", profile.UiVisibility)
+	d.Set("allowed_actions", profile.AllowedActions)
+	d.Set("enrollment_key", profile.EnrollmentKey)
+	d.Set("enrollment_url", profile.EnrollmentUrl)
+
+	return nil
+}
+
+func resourceAgentConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/agent-configurations/"+d.Id(), agentConfigurationPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceAgentConfigurationRead(ctx, d, m)
+}
+
+func resourceAgentConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/agent-configurations/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// brandingID is the ID of the organization-wide portal branding, of which there is exactly one
+const brandingID = "branding"
+
+// maxLogoSize is the largest logo file the portal accepts, in bytes
+const maxLogoSize = 1 << 20
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// ResourceBranding manages the branding of the Portnox end-user portals. Creating the resource adopts the existing
+// branding, and destroying it restores the default Portnox branding.
+func ResourceBranding() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBrandingCreate,
+		ReadContext:   resourceBrandingRead,
+		UpdateContext: resourceBrandingUpdate,
+		DeleteContext: resourceBrandingDelete,
+		CustomizeDiff: resourceBrandingCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateFixedID(brandingID, ResourceBranding),
+		},
+		Schema: map[string]*schema.Schema{
+			"company_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The company name shown in the portals.",
+			},
+			"logo_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"logo_base64"},
+				Description:   "The path of a local PNG, JPEG, or SVG logo file, uploaded base64-encoded. Changes to the file content are detected.",
+			},
+			"logo_base64": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"logo_file"},
+				ValidateFunc:  validation.StringIsBase64,
+				Description:   "The base64-encoded logo, e.g. from filebase64(). Use instead of logo_file.",
+			},
+			"logo_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 hash of the uploaded logo.",
+			},
+			"primary_color": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(hexColorPattern, "must be a hex color such as #0A3D62"),
+				Description:  "The primary color of the portals, as a hex color such as #0A3D62.",
+			},
+			"secondary_color": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(hexColorPattern, "must be a hex color such as #0A3D62"),
+				Description:  "The secondary color of the portals, as a hex color.",
+			},
+			"support_email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The support email address shown to end users.",
+			},
+			"support_phone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The support phone number shown to end users.",
+			},
+			"support_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+				Description:  "The support page linked from the portals.",
+			},
+			"login_text": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Custom text shown on the portal login page, such as an acceptable use notice.",
+			},
+		},
+	}
+}
+
+// brandingLogo returns the configured logo, read from logo_file or decoded from logo_base64
+func brandingLogo(logoFile, logoBase64 string) ([]byte, error) {
+	if logoFile != "" {
+		logo, err := os.ReadFile(logoFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading logo_file: %s", err)
+		}
+		if len(logo) > maxLogoSize {
+			return nil, fmt.Errorf("logo_file %s is larger than the %d byte limit", logoFile, maxLogoSize)
+		}
+		return logo, nil
+	}
+	if logoBase64 != "" {
+		logo, err := base64.StdEncoding.DecodeString(logoBase64)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding logo_base64: %s", err)
+		}
+		if len(logo) > maxLogoSize {
+			return nil, fmt.Errorf("logo_base64 is larger than the %d byte limit", maxLogoSize)
+		}
+		return logo, nil
+	}
+	return nil, nil
+}
+
+// logoSha256 returns the hex-encoded SHA-256 hash of a logo, or an empty string when there is none
+func logoSha256(logo []byte) string {
+	if logo == nil {
+		return ""
+	}
+	sum := sha256.Sum256(logo)
+	return hex.EncodeToString(sum[:])
+}
+
+// resourceBrandingCustomizeDiff plans logo_sha256 from the logo content, so edits to logo_file show up in the plan
+func resourceBrandingCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("logo_file") || !d.NewValueKnown("logo_base64") {
+		return d.SetNewComputed("logo_sha256")
+	}
+
+	logo, err := brandingLogo(d.Get("logo_file").(string), d.Get("logo_base64").(string))
+	if err != nil {
+		return err
+	}
+	if hash := logoSha256(logo); hash != d.Get("logo_sha256").(string) {
+		return d.SetNew("logo_sha256", hash)
+	}
+	return nil
+}
+
+// brandingPayload builds the API representation of the branding from the resource data
+func brandingPayload(d *schema.ResourceData) (map[string]interface{}, error) {
+	payload := map[string]interface{}{
+		"CompanyName":    d.Get("company_name").(string),
+		"PrimaryColor":   d.Get("primary_color").(string),
+		"SecondaryColor": d.Get("secondary_color").(string),
+		"SupportEmail":   d.Get("support_email").(string),
+		"SupportPhone":   d.Get("support_phone").(string),
+		"SupportUrl":     d.Get("support_url").(string),
+		"LoginText":      d.Get("login_text").(string),
+	}
+
+	// Only upload the logo when it changed, as it can be large
+	if d.IsNewResource() || d.HasChange("logo_sha256") {
+		logo, err := brandingLogo(d.Get("logo_file").(string), d.Get("logo_base64").(string))
+		if err != nil {
+			return nil, err
+		}
+		payload["Logo"] = base64.StdEncoding.EncodeToString(logo)
+		payload["LogoSha256"] = logoSha256(logo)
+	}
+
+	return payload, nil
+}
+
+func resourceBrandingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, err := brandingPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/branding", payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	d.SetId(brandingID)
+
+	return resourceBrandingRead(ctx, d, m)
+}
+
+func resourceBrandingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/branding", nil)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var branding struct {
+		CompanyName    string `json:"CompanyName"`
+		LogoSha256     string `json:"LogoSha256"`
+		PrimaryColor   string `json:"PrimaryColor"`
+		SecondaryColor string `json:"SecondaryColor"`
+		SupportEmail   string `json:"SupportEmail"`
+		SupportPhone   string `json:"SupportPhone"`
+		SupportUrl     string `json:"SupportUrl"`
+		LoginText      string `json:"LoginText"`
+	}
+	if err := json.Unmarshal(responseBody, &branding); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("company_name", branding.CompanyName)
+	// Keep the hash of the uploaded logo when the API does not report one
+	if branding.LogoSha256 != "" {
+		d.Set("logo_sha256", branding.LogoSha256)
+	}
+	d.Set("primary_color", branding.PrimaryColor)
+	d.Set("secondary_color", branding.SecondaryColor)
+	d.Set("support_email", branding.SupportEmail)
+	d.Set("support_phone", branding.SupportPhone)
+	d.Set("support_url", branding.SupportUrl)
+	d.Set("login_text", branding.LoginText)
+
+	return nil
+}
+
+func resourceBrandingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, err := brandingPayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/branding", payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceBrandingRead(ctx, d, m)
+}
+
+func resourceBrandingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	// The branding cannot be deleted, so restore the defaults instead
+	if _, err := config.MakeRequestWithRetry(ctx, "POST", "/api/branding/reset", nil); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceChildOrganization manages a child organization of a managed service provider through the partner API. The
+// organization is created with its initial administrator and an API key, so a new tenant can be onboarded and then
+// configured through an aliased provider in a single apply. It requires an API key of a partner organization.
+func ResourceChildOrganization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceChildOrganizationCreate,
+		ReadContext:   resourceChildOrganizationRead,
+		UpdateContext: resourceChildOrganizationUpdate,
+		DeleteContext: resourceChildOrganizationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceChildOrganizationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The name of the child organization.",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The region the child organization is hosted in. Defaults to the region of the partner organization.",
+			},
+			"license_seats": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of license seats allocated to the child organization from the partner pool.",
+			},
+			"enabled_features": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The features enabled for the child organization, such as NAC, ZTNA, or TACACS.",
+			},
+			"admin_email": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The email address of the initial administrator of the child organization, who is invited by email.",
+			},
+			"admin_first_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The first name of the initial administrator.",
+			},
+			"admin_last_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The last name of the initial administrator.",
+			},
+			"api_key_description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "Terraform",
+				Description: "The description of the API key issued for the child organization, shown in its audit log.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the child organization.",
+			},
+			"admin_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the initial administrator.",
+			},
+			"api_key_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the API key issued for the child organization.",
+			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API key issued for the child organization. The API only returns it when the organization is created, so it is kept in state and is empty after import.",
+			},
+		},
+	}
+}
+
+// childOrganizationSettings builds the API representation of the settings of the child organization that can be
+// changed after it is created
+func childOrganizationSettings(d *schema.ResourceData) map[string]interface{} {
+	settings := map[string]interface{}{
+		"Name": d.Get("name").(string),
+	}
+	if seats, ok := d.GetOk("license_seats"); ok {
+		settings["LicenseSeats"] = seats.(int)
+	}
+	if features, ok := d.GetOk("enabled_features"); ok {
+		settings["EnabledFeatures"] = expandStringList(features.(*schema.Set).List())
+	}
+	return settings
+}
+
+func resourceChildOrganizationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload := childOrganizationSettings(d)
+	if region := d.Get("region").(string); region != "" {
+		payload["Region"] = region
+	}
+	payload["InitialAdmin"] = map[string]interface{}{
+		"Email":     d.Get("admin_email").(string),
+		"FirstName": d.Get("admin_first_name").(string),
+		"LastName":  d.Get("admin_last_name").(string),
+	}
+	payload["ApiKey"] = map[string]interface{}{
+		"Description": d.Get("api_key_description").(string),
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/partner/organizations", payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var organization struct {
+		Id           string `json:"Id"`
+		InitialAdmin struct {
+			Id string `json:"Id"`
+		} `json:"InitialAdmin"`
+		ApiKey struct {
+			Id  string `json:"Id"`
+			Key string `json:"Key"`
+		} `json:"ApiKey"`
+	}
+	if err := json.Unmarshal(responseBody, &organization); err != nil {
+		return diag.FromErr(err)
+	}
+	if organization.Id == "" {
+		return diag.Errorf("the API did not return an ID for child organization %s", d.Get("name").(string))
+	}
+
+	d.SetId(organization.Id)
+	d.Set("admin_id", organization.InitialAdmin.Id)
+	d.Set("api_key_id", organization.ApiKey.Id)
+	d.Set("api_key", organization.ApiKey.Key)
+
+	return resourceChildOrganizationRead(ctx, d, m)
+}
+
+func resourceChildOrganizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/partner/organizations/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_child_organization", "child organization not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var organization struct {
+		Name            string   `json:"Name"`
+		Region          string   `json:"Region"`
+		LicenseSeats    int      `json:"LicenseSeats"`
+		EnabledFeatures []string `json:"EnabledFeatures"`
+	}
+	if err := json.Unmarshal(responseBody, &organization); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("org_id", d.Id())
+	d.Set("name", organization.Name)
+	d.Set("region", organization.Region)
+	d.Set("license_seats", organization.LicenseSeats)
+	d.Set("enabled_features", organization.EnabledFeatures)
+
+	return nil
+}
+
+// resourceChildOrganizationImport imports a child organization by ID. The initial administrator is set from the
+// organization so the imported state matches it; the API key cannot be read back and is left empty.
+func resourceChildOrganizationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := importStateID("the ID of the child organization", ResourceChildOrganization)(ctx, d, m); err != nil {
+		return nil, err
+	}
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/partner/organizations/"+d.Id(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving child organization %s: %s", d.Id(), err)
+	}
+
+	var organization struct {
+		InitialAdmin struct {
+			Id        string `json:"Id"`
+			Email     string `json:"Email"`
+			FirstName string `json:"FirstName"`
+			LastName  string `json:"LastName"`
+		} `json:"InitialAdmin"`
+	}
+	if err := json.Unmarshal(responseBody, &organization); err != nil {
+		return nil, fmt.Errorf("error parsing API response: %s", err)
+	}
+
+	d.Set("admin_id", organization.InitialAdmin.Id)
+	d.Set("admin_email", organization.InitialAdmin.Email)
+	d.Set("admin_first_name", organization.InitialAdmin.FirstName)
+	d.Set("admin_last_name", organization.InitialAdmin.LastName)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceChildOrganizationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/partner/organizations/"+d.Id(), childOrganizationSettings(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceChildOrganizationRead(ctx, d, m)
+}
+
+func resourceChildOrganizationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/partner/organizations/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
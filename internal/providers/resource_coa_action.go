@@ -0,0 +1,126 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceCoaAction issues a RADIUS Change-of-Authorization for a device or session when it is created, and
+// again whenever trigger changes. It has no remote state: destroying it only removes it from state.
+func ResourceCoaAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCoaActionCreate,
+		ReadContext:   resourceCoaActionRead,
+		DeleteContext: resourceCoaActionDelete,
+		Schema: map[string]*schema.Schema{
+			"mac_address": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"mac_address", "session_id"},
+				ValidateFunc:     validation.StringMatch(macAddressPattern, macAddressMessage),
+				StateFunc:        normalizeMacAddressState,
+				DiffSuppressFunc: suppressEquivalentMacAddress,
+				Description:      "The MAC address of the device whose sessions receive the CoA.",
+			},
+			"session_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the session that receives the CoA.",
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "reauthenticate",
+				ValidateFunc: validation.StringInSlice([]string{"reauthenticate", "disconnect", "bounce_port"}, false),
+				Description:  "The CoA to issue. One of reauthenticate, disconnect, or bounce_port.",
+			},
+			"trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "An arbitrary value that issues the CoA again whenever it changes, e.g. the ID of a policy revision.",
+			},
+			"sessions_affected": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of sessions the CoA was sent to.",
+			},
+			"issued_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the CoA was issued.",
+			},
+		},
+	}
+}
+
+func resourceCoaActionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload := map[string]interface{}{
+		"Action": d.Get("action").(string),
+	}
+	attribute := "session_id"
+	if macAddress := d.Get("mac_address").(string); macAddress != "" {
+		payload["MacAddress"] = macAddress
+		attribute = "mac_address"
+	} else {
+		payload["SessionId"] = d.Get("session_id").(string)
+	}
+
+	// A CoA acts on sessions rather than on the configuration, so it is sent even when validate_only is set
+	responseBody, err := config.MakeRequestWithRetry(common.WithoutValidateOnly(ctx), "POST", "/api/sessions/coa", payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, attribute)
+	}
+
+	var result struct {
+		RequestId        string `json:"RequestId"`
+		SessionsAffected int    `json:"SessionsAffected"`
+	}
+	if err := json.Unmarshal(responseBody, &result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	issuedAt := time.Now().UTC().Format(time.RFC3339)
+	id := result.RequestId
+	if id == "" {
+		id = d.Get(attribute).(string) + "@" + issuedAt
+	}
+
+	d.SetId(id)
+	d.Set("sessions_affected", result.SessionsAffected)
+	d.Set("issued_at", issuedAt)
+
+	var diags diag.Diagnostics
+	if result.SessionsAffected == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "No active sessions",
+			Detail:   "The CoA was accepted but no active session matched, so no device was re-evaluated.",
+		})
+	}
+
+	return diags
+}
+
+func resourceCoaActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// A CoA is a one-off action with nothing to refresh
+	return nil
+}
+
+func resourceCoaActionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
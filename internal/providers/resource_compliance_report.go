@@ -0,0 +1,227 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// complianceReportPollInterval is how often the status of a report being generated is checked
+const complianceReportPollInterval = 5 * time.Second
+
+// ResourceComplianceReport generates a compliance report for a time range when it is created, and again whenever
+// trigger changes, and stores it in a local file or exposes its content. Destroying it only removes it from state:
+// generated reports are kept by Portnox for their retention period.
+func ResourceComplianceReport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceComplianceReportCreate,
+		ReadContext:   resourceComplianceReportRead,
+		DeleteContext: resourceComplianceReportDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"start_time": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The start of the reported time range, in RFC 3339 format.",
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The end of the reported time range, in RFC 3339 format.",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "csv",
+				ValidateFunc: validation.StringInSlice([]string{"csv", "json"}, false),
+				Description:  "The format of the report: csv or json.",
+			},
+			"site_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Only report on the devices of this site.",
+			},
+			"output_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The local path the report is written to. The report is generated again when the file is deleted or modified. When not set, the report is exposed in content.",
+			},
+			"trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "An arbitrary value that generates the report again whenever it changes, e.g. for scheduled runs.",
+			},
+			"report_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the generated report.",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content of the report. Only set when output_path is not set.",
+			},
+			"content_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 checksum of the report, in hex, for evidence integrity.",
+			},
+			"generated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the report was generated.",
+			},
+		},
+	}
+}
+
+// waitForComplianceReport polls the report until it is generated and returns the time it was generated
+func waitForComplianceReport(ctx context.Context, config *common.Config, reportID string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/compliance-reports/"+reportID, nil)
+		if err != nil {
+			return "", err
+		}
+
+		var report struct {
+			Status      string `json:"Status"`
+			Error       string `json:"Error"`
+			GeneratedAt string `json:"GeneratedAt"`
+		}
+		if err := json.Unmarshal(responseBody, &report); err != nil {
+			return "", err
+		}
+
+		switch strings.ToLower(report.Status) {
+		case "completed":
+			return report.GeneratedAt, nil
+		case "failed":
+			return "", fmt.Errorf("generating compliance report %s failed: %s", reportID, report.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("timed out waiting for compliance report %s to be generated, last status %q", reportID, report.Status)
+		case <-time.After(complianceReportPollInterval):
+		}
+	}
+}
+
+func resourceComplianceReportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	startTime, _ := time.Parse(time.RFC3339, d.Get("start_time").(string))
+	endTime, _ := time.Parse(time.RFC3339, d.Get("end_time").(string))
+	if !endTime.After(startTime) {
+		return diag.Errorf("end_time must be after start_time")
+	}
+
+	payload := map[string]interface{}{
+		"From":   d.Get("start_time").(string),
+		"To":     d.Get("end_time").(string),
+		"Format": d.Get("format").(string),
+	}
+	if siteID := d.Get("site_id").(string); siteID != "" {
+		payload["SiteId"] = siteID
+	}
+
+	// Generating a report changes no configuration, so it is requested even when validate_only is set
+	responseBody, err := config.MakeRequestWithRetry(common.WithoutValidateOnly(ctx), "POST", "/api/compliance-reports", payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var report struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &report); err != nil {
+		return diag.FromErr(err)
+	}
+	if report.Id == "" {
+		return diag.Errorf("the API did not return an ID for the compliance report")
+	}
+
+	generatedAt, err := waitForComplianceReport(ctx, config, report.Id, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	content, err := config.MakeRequestWithRetry(ctx, "GET", "/api/compliance-reports/"+report.Id+"/content", nil)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+	checksum := sha256.Sum256(content)
+
+	if outputPath := d.Get("output_path").(string); outputPath != "" {
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+			return diag.Errorf("error creating the directory of output_path: %s", err)
+		}
+		if err := os.WriteFile(outputPath, content, 0o600); err != nil {
+			return diag.Errorf("error writing the compliance report to output_path: %s", err)
+		}
+	} else {
+		d.Set("content", string(content))
+	}
+
+	d.SetId(report.Id)
+	d.Set("report_id", report.Id)
+	d.Set("content_sha256", hex.EncodeToString(checksum[:]))
+	d.Set("generated_at", generatedAt)
+
+	return nil
+}
+
+func resourceComplianceReportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	outputPath := d.Get("output_path").(string)
+	if outputPath == "" {
+		// A generated report does not change, so there is nothing to refresh
+		return nil
+	}
+
+	// Generate the report again if the archived file is gone or no longer matches what was generated
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return removeFromState(d, "portnox_compliance_report", "output_path "+outputPath+" not found")
+		}
+		return diag.FromErr(err)
+	}
+	checksum := sha256.Sum256(content)
+	if hex.EncodeToString(checksum[:]) != d.Get("content_sha256").(string) {
+		return removeFromState(d, "portnox_compliance_report", "output_path "+outputPath+" was modified")
+	}
+
+	return nil
+}
+
+func resourceComplianceReportDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The report is kept by Portnox, and the file at output_path is kept as evidence
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// timeOfDayPattern matches a 24-hour HH:MM time of day
+var timeOfDayPattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// daysOfWeek are the day names accepted in time conditions and access schedules
+var daysOfWeek = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// ResourceConditionalAccessRule manages a ZTNA conditional access rule. A rule matches when all of its
+// configured conditions match, and then allows, denies, or requires step-up authentication.
+func ResourceConditionalAccessRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConditionalAccessRuleCreate,
+		ReadContext:   resourceConditionalAccessRuleRead,
+		UpdateContext: resourceConditionalAccessRuleUpdate,
+		DeleteContext: resourceConditionalAccessRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the rule", ResourceConditionalAccessRule),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the conditional access rule.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the conditional access rule.",
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The evaluation order of the rule. Rules with a lower priority are evaluated first.",
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny", "step_up"}, false),
+				Description:  "The action taken when the rule matches. One of allow, deny, or step_up.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Indicates whether the rule is evaluated.",
+			},
+			"application_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the ZTNA applications the rule applies to. When empty, the rule applies to all applications.",
+			},
+			"user_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the user groups the rule matches. When empty, the rule matches all users.",
+			},
+			"device_posture": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "any",
+				ValidateFunc: validation.StringInSlice([]string{"any", "compliant", "non_compliant"}, false),
+				Description:  "The device posture the rule matches. One of any, compliant, or non_compliant.",
+			},
+			"countries": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringLenBetween(2, 2)},
+				Description: "The ISO 3166-1 alpha-2 codes of the countries the rule matches. When empty, the rule matches all locations.",
+			},
+			"time_condition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(daysOfWeek, false),
+							},
+							Description: "The days of the week the rule matches.",
+						},
+						"start_time": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(timeOfDayPattern, "must be a time of day in HH:MM format"),
+							Description:  "The time of day the rule starts matching, in HH:MM format.",
+						},
+						"end_time": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringMatch(timeOfDayPattern, "must be a time of day in HH:MM format"),
+							Description:  "The time of day the rule stops matching, in HH:MM format.",
+						},
+						"timezone": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "UTC",
+							Description: "The IANA time zone of start_time and end_time.",
+						},
+					},
+				},
+				Description: "The days and times of day the rule matches. When unset, the rule matches at all times.",
+			},
+		},
+	}
+}
+
+// conditionalAccessRulePayload builds the API representation of the rule from the resource data
+func conditionalAccessRulePayload(d *schema.ResourceData) map[string]interface{} {
+	payload := map[string]interface{}{
+		"Name":           d.Get("name").(string),
+		"Description":    d.Get("description").(string),
+		"Priority":       d.Get("priority").(int),
+		"Action":         d.Get("action").(string),
+		"Enabled":        d.Get("enabled").(bool),
+		"ApplicationIds": expandStringList(d.Get("application_ids").(*schema.Set).List()),
+		"UserGroupIds":   expandStringList(d.Get("user_group_ids").(*schema.Set).List()),
+		"DevicePosture":  d.Get("device_posture").(string),
+		"Countries":      expandStringList(d.Get("countries").(*schema.Set).List()),
+	}
+
+	if conditions := d.Get("time_condition").([]interface{}); len(conditions) > 0 && conditions[0] != nil {
+		condition := conditions[0].(map[string]interface{})
+		payload["TimeCondition"] = map[string]interface{}{
+			"Days":      expandStringList(condition["days"].(*schema.Set).List()),
+			"StartTime": condition["start_time"].(string),
+			"EndTime":   condition["end_time"].(string),
+			"Timezone":  condition["timezone"].(string),
+		}
+	}
+
+	return payload
+}
+
+func resourceConditionalAccessRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/conditional-access-rules", conditionalAccessRulePayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var rule struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &rule); err != nil {
+		return diag.FromErr(err)
+	}
+	if rule.Id == "" {
+		return diag.Errorf("the API did not return an ID for conditional access rule %s", d.Get("name").(string))
+	}
+
+	d.SetId(rule.Id)
+
+	return resourceConditionalAccessRuleRead(ctx, d, m)
+}
+
+func resourceConditionalAccessRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/conditional-access-rules/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_conditional_access_rule", "conditional access rule not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var rule struct {
+		Name           string   `json:"Name"`
+		Description    string   `json:"Description"`
+		Priority       int      `json:"Priority"`
+		Action         string   `json:"Action"`
+		Enabled        bool     `json:"Enabled"`
+		ApplicationIds []string `json:"ApplicationIds"`
+		UserGroupIds   []string `json:"UserGroupIds"`
+		DevicePosture  string   `json:"DevicePosture"`
+		Countries      []string `json:"Countries"`
+		TimeCondition  *struct {
+			Days      []string `json:"Days"`
+			StartTime string   `json:"StartTime"`
+			EndTime   string   `json:"EndTime"`
+			Timezone  string   `json:"Timezone"`
+		} `json:"TimeCondition"`
+	}
+	if err := json.Unmarshal(responseBody, &rule); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", rule.Name)
+	d.Set("description", rule.Description)
+	d.Set("priority", rule.Priority)
+	d.Set("action", rule.Action)
+	d.Set("enabled", rule.Enabled)
+	d.Set("application_ids", rule.ApplicationIds)
+	d.Set("user_group_ids", rule.UserGroupIds)
+	d.Set("device_posture", rule.DevicePosture)
+	d.Set("countries", rule.Countries)
+
+	var timeCondition []map[string]interface{}
+	if rule.TimeCondition != nil {
+		timeCondition = append(timeCondition, map[string]interface{}{
+			"days":       rule.TimeCondition.Days,
+			"start_time": rule.TimeCondition.StartTime,
+			"end_time":   rule.TimeCondition.EndTime,
+			"timezone":   rule.TimeCondition.Timezone,
+		})
+	}
+	if err := d.Set("time_condition", timeCondition); err != nil {
+		return diag.Errorf("error setting time_condition: %s", err)
+	}
+
+	return nil
+}
+
+func resourceConditionalAccessRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/conditional-access-rules/"+d.Id(), conditionalAccessRulePayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceConditionalAccessRuleRead(ctx, d, m)
+}
+
+func resourceConditionalAccessRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/conditional-access-rules/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dhcpFingerprintPattern matches a DHCP option 55 parameter request list such as 1,3,6,15,119,252
+var dhcpFingerprintPattern = regexp.MustCompile(`^\d{1,3}(,\d{1,3})*$`)
+
+// deviceProfilingConditions are the attributes a profiling rule matches devices on, at least one of which is required
+var deviceProfilingConditions = []string{"dhcp_fingerprint", "dhcp_vendor_class", "mac_prefixes", "hostname_patterns"}
+
+// ResourceDeviceProfilingRule manages a custom device profiling rule that classifies devices by their DHCP
+// fingerprint, MAC OUI, or hostname
+func ResourceDeviceProfilingRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeviceProfilingRuleCreate,
+		ReadContext:   resourceDeviceProfilingRuleRead,
+		UpdateContext: resourceDeviceProfilingRuleUpdate,
+		DeleteContext: resourceDeviceProfilingRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the rule", ResourceDeviceProfilingRule),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the profiling rule.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the profiling rule.",
+			},
+			"device_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The device type assigned to matching devices, e.g. IP Camera or Printer.",
+			},
+			"vendor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The vendor assigned to matching devices.",
+			},
+			"operating_system": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The operating system assigned to matching devices.",
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "The evaluation order of the rule. Rules with a lower priority are evaluated first.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Indicates whether the rule is used to classify devices.",
+			},
+			"match_all": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Require all configured conditions to match. When false, any single condition classifies the device.",
+			},
+			"dhcp_fingerprint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: deviceProfilingConditions,
+				ValidateFunc: validation.StringMatch(dhcpFingerprintPattern, "must be a comma-separated list of DHCP option numbers (e.g. 1,3,6,15,119,252)"),
+				Description:  "The DHCP option 55 parameter request list sent by the device, as comma-separated option numbers.",
+			},
+			"dhcp_vendor_class": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: deviceProfilingConditions,
+				Description:  "The DHCP option 60 vendor class identifier sent by the device.",
+			},
+			"mac_prefixes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+						if _, ok := macHex(v.(string)); !ok {
+							errs = append(errs, fmt.Errorf("%s must be an OUI or MAC prefix (e.g. AA:BB:CC or AABBCC)", k))
+						}
+						return
+					},
+				},
+				AtLeastOneOf: deviceProfilingConditions,
+				Description:  "The OUIs or MAC prefixes matched against the device MAC address.",
+			},
+			"hostname_patterns": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsValidRegExp},
+				AtLeastOneOf: deviceProfilingConditions,
+				Description:  "Regular expressions matched against the hostname reported by the device.",
+			},
+		},
+	}
+}
+
+// deviceProfilingRulePayload builds the API representation of the profiling rule from the resource data
+func deviceProfilingRulePayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"Name":             d.Get("name").(string),
+		"Description":      d.Get("description").(string),
+		"DeviceType":       d.Get("device_type").(string),
+		"Vendor":           d.Get("vendor").(string),
+		"OperatingSystem":  d.Get("operating_system").(string),
+		"Priority":         d.Get("priority").(int),
+		"Enabled":          d.Get("enabled").(bool),
+		"MatchAll":         d.Get("match_all").(bool),
+		"DhcpFingerprint":  d.Get("dhcp_fingerprint").(string),
+		"DhcpVendorClass":  d.Get("dhcp_vendor_class").(string),
+		"MacPrefixes":      expandStringList(d.Get("mac_prefixes").(*schema.Set).List()),
+		"HostnamePatterns": expandStringList(d.Get("hostname_patterns").([]interface{})),
+	}
+}
+
+func resourceDeviceProfilingRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/device-profiling-rules", deviceProfilingRulePayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var rule struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &rule); err != nil {
+		return diag.FromErr(err)
+	}
+	if rule.Id == "" {
+		return diag.Errorf("the API did not return an ID for device profiling rule %s", d.Get("name").(string))
+	}
+
+	d.SetId(rule.Id)
+
+	return resourceDeviceProfilingRuleRead(ctx, d, m)
+}
+
+func resourceDeviceProfilingRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/device-profiling-rules/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_device_profiling_rule", "device profiling rule not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var rule struct {
+		Name             string   `json:"Name"`
+		Description      string   `json:"Description"`
+		DeviceType       string   `json:"DeviceType"`
+		Vendor           string   `json:"Vendor"`
+		OperatingSystem  string   `json:"OperatingSystem"`
+		Priority         int      `json:"Priority"`
+		Enabled          bool     `json:"Enabled"`
+		MatchAll         bool     `json:"MatchAll"`
+		DhcpFingerprint  string   `json:"DhcpFingerprint"`
+		DhcpVendorClass  string   `json:"DhcpVendorClass"`
+		MacPrefixes      []string `json:"MacPrefixes"`
+		HostnamePatterns []string `json:"HostnamePatterns"`
+	}
+	if err := json.Unmarshal(responseBody, &rule); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", rule.Name)
+	d.Set("description", rule.Description)
+	d.Set("device_type", rule.DeviceType)
+	d.Set("vendor", rule.Vendor)
+	d.Set("operating_system", rule.OperatingSystem)
+	d.Set("priority", rule.Priority)
+	d.Set("enabled", rule.Enabled)
+	d.Set("match_all", rule.MatchAll)
+	d.Set("dhcp_fingerprint", rule.DhcpFingerprint)
+	d.Set("dhcp_vendor_class", rule.DhcpVendorClass)
+	d.Set("mac_prefixes", rule.MacPrefixes)
+	d.Set("hostname_patterns", rule.HostnamePatterns)
+
+	return nil
+}
+
+func resourceDeviceProfilingRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/device-profiling-rules/"+d.Id(), deviceProfilingRulePayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceDeviceProfilingRuleRead(ctx, d, m)
+}
+
+func resourceDeviceProfilingRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/device-profiling-rules/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceLdapIntegration manages an on-premises Active Directory or LDAP integration reached through a Portnox
+// directory broker, and exposes the key used to enroll the broker
+func ResourceLdapIntegration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLdapIntegrationCreate,
+		ReadContext:   resourceLdapIntegrationRead,
+		UpdateContext: resourceLdapIntegrationUpdate,
+		DeleteContext: resourceLdapIntegrationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the directory integration", ResourceLdapIntegration),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the directory integration.",
+			},
+			"directory_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active_directory",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"active_directory", "ldap"}, false),
+				Description:  "The type of the directory. One of active_directory or ldap.",
+			},
+			"servers": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The directory servers queried by the broker, as host or host:port, in order of preference.",
+			},
+			"use_ldaps": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Indicates whether the broker connects to the directory servers over LDAPS.",
+			},
+			"base_dn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The base DN searched for users and groups, e.g. DC=corp,DC=example,DC=com.",
+			},
+			"bind_dn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The DN of the service account the broker binds with.",
+			},
+			"bind_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"bind_password", "bind_password_wo"},
+				Description:  "The password of the bind service account. The API never returns the password, so changes made outside of Terraform are not detected.",
+			},
+			"bind_password_wo": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The password of the bind service account as a write-only argument, which is never stored in state. Requires Terraform 1.11 or later. The password is only sent on create and when bind_password_wo_version changes.",
+			},
+			"bind_password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"bind_password_wo"},
+				Description:  "A version number for bind_password_wo. Change it to send the current bind_password_wo value.",
+			},
+			"sync_scope": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validation.StringInSlice([]string{"all", "groups"}, false),
+				Description:  "Which users are synchronized. One of all, or groups to only synchronize members of group_filters.",
+			},
+			"group_filters": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The DNs of the groups whose members are synchronized when sync_scope is groups.",
+			},
+			"user_filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An additional LDAP filter applied to synchronized users, e.g. (!(userAccountControl:1.2.840.113556.1.4.803:=2)).",
+			},
+			"sync_interval_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				ValidateFunc: validation.IntBetween(5, 1440),
+				Description:  "The interval between directory synchronizations, in minutes.",
+			},
+			"broker_enrollment_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The key used to enroll the on-premises directory broker with this integration.",
+			},
+			"broker_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The connection status of the directory broker.",
+			},
+		},
+	}
+}
+
+// ldapIntegrationPayload builds the API representation of the directory integration from the resource data
+func ldapIntegrationPayload(d *schema.ResourceData) (map[string]interface{}, diag.Diagnostics) {
+	payload := map[string]interface{}{
+		"Name":                d.Get("name").(string),
+		"DirectoryType":       d.Get("directory_type").(string),
+		"Servers":             expandStringList(d.Get("servers").([]interface{})),
+		"UseLdaps":            d.Get("use_ldaps").(bool),
+		"BaseDn":              d.Get("base_dn").(string),
+		"BindDn":              d.Get("bind_dn").(string),
+		"SyncScope":           d.Get("sync_scope").(string),
+		"GroupFilters":        expandStringList(d.Get("group_filters").([]interface{})),
+		"UserFilter":          d.Get("user_filter").(string),
+		"SyncIntervalMinutes": d.Get("sync_interval_minutes").(int),
+	}
+
+	// Only send the bind password when it is set or changed, so updates do not reset it
+	password, changed, diags := changedSecret(d, "bind_password", "bind_password_wo", "bind_password_wo_version")
+	if diags.HasError() {
+		return nil, diags
+	}
+	if changed {
+		payload["BindPassword"] = password
+	}
+
+	return payload, nil
+}
+
+func resourceLdapIntegrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := ldapIntegrationPayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/directory-integrations", payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var integration struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &integration); err != nil {
+		return diag.FromErr(err)
+	}
+	if integration.Id == "" {
+		return diag.Errorf("the API did not return an ID for directory integration %s", d.Get("name").(string))
+	}
+
+	d.SetId(integration.Id)
+
+	return resourceLdapIntegrationRead(ctx, d, m)
+}
+
+func resourceLdapIntegrationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/directory-integrations/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_ldap_integration", "directory integration not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var integration struct {
+		Name                string   `json:"Name"`
+		DirectoryType       string   `json:"DirectoryType"`
+		Servers             []string `json:"Servers"`
+		UseLdaps            bool     `json:"UseLdaps"`
+		BaseDn              string   `json:"BaseDn"`
+		BindDn              string   `json:"BindDn"`
+		SyncScope           string   `json:"SyncScope"`
+		GroupFilters        []string `json:"GroupFilters"`
+		UserFilter          string   `json:"UserFilter"`
+		SyncIntervalMinutes int      `json:"SyncIntervalMinutes"`
+		BrokerEnrollmentKey string   `json:"BrokerEnrollmentKey"`
+		BrokerStatus        string   `json:"BrokerStatus"`
+	}
+	if err := json.Unmarshal(responseBody, &integration); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", integration.Name)
+	d.Set("directory_type", integration.DirectoryType)
+	d.Set("servers", integration.Servers)
+	d.Set("use_ldaps", integration.UseLdaps)
+	d.Set("base_dn", integration.BaseDn)
+	d.Set("bind_dn", integration.BindDn)
+	d.Set("sync_scope", integration.SyncScope)
+	d.Set("group_filters", integration.GroupFilters)
+	d.Set("user_filter", integration.UserFilter)
+	d.Set("sync_interval_minutes", integration.SyncIntervalMinutes)
+	d.Set("broker_enrollment_key", integration.BrokerEnrollmentKey)
+	d.Set("broker_status", integration.BrokerStatus)
+
+	return nil
+}
+
+func resourceLdapIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := ldapIntegrationPayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/directory-integrations/"+d.Id(), payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceLdapIntegrationRead(ctx, d, m)
+}
+
+func resourceLdapIntegrationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/directory-integrations/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceLocalUser manages a user account defined locally in Portnox rather than synchronized from a directory
+func ResourceLocalUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLocalUserCreate,
+		ReadContext:   resourceLocalUserRead,
+		UpdateContext: resourceLocalUserUpdate,
+		DeleteContext: resourceLocalUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the user", ResourceLocalUser),
+		},
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The username of the user.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The email address of the user.",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The display name of the user.",
+			},
+			"group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the user groups the user is a member of.",
+			},
+			"authentication_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "password",
+				ValidateFunc: validation.StringInSlice([]string{"password", "certificate", "password_and_certificate"}, false),
+				Description:  "How the user authenticates. One of password, certificate, or password_and_certificate.",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of the user. When unset for password authentication, the user is invited to set one by email. The API never returns the password, so changes made outside of Terraform are not detected.",
+			},
+			"password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"password"},
+				Description:   "The password of the user as a write-only argument, which is never stored in state. Requires Terraform 1.11 or later. The password is only sent on create and when password_wo_version changes.",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "A version number for password_wo. Change it to send the current password_wo value.",
+			},
+			"certificate_validity_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      365,
+				ValidateFunc: validation.IntBetween(1, 3650),
+				Description:  "The validity period of the certificates issued to the user for certificate authentication, in days.",
+			},
+			"expiration": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The date and time the user account expires, in RFC 3339 format.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Indicates whether the user can authenticate.",
+			},
+		},
+	}
+}
+
+// localUserPayload builds the API representation of the user from the resource data
+func localUserPayload(d *schema.ResourceData) (map[string]interface{}, diag.Diagnostics) {
+	payload := map[string]interface{}{
+		"Username":                d.Get("username").(string),
+		"Email":                   d.Get("email").(string),
+		"DisplayName":             d.Get("display_name").(string),
+		"GroupIds":                expandStringList(d.Get("group_ids").(*schema.Set).List()),
+		"AuthenticationMethod":    d.Get("authentication_method").(string),
+		"CertificateValidityDays": d.Get("certificate_validity_days").(int),
+		"Enabled":                 d.Get("enabled").(bool),
+	}
+
+	// Only send the password when it is set or changed, so updates do not reset it
+	password, changed, diags := changedSecret(d, "password", "password_wo", "password_wo_version")
+	if diags.HasError() {
+		return nil, diags
+	}
+	if changed && password != "" {
+		payload["Password"] = password
+	}
+	if expiration := d.Get("expiration").(string); expiration != "" {
+		payload["Expiration"] = expiration
+	}
+
+	return payload, nil
+}
+
+func resourceLocalUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := localUserPayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/users", payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "username")
+	}
+
+	var user struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &user); err != nil {
+		return diag.FromErr(err)
+	}
+	if user.Id == "" {
+		return diag.Errorf("the API did not return an ID for user %s", d.Get("username").(string))
+	}
+
+	d.SetId(user.Id)
+
+	return resourceLocalUserRead(ctx, d, m)
+}
+
+func resourceLocalUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/users/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_local_user", "user not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var user struct {
+		Username                string   `json:"Username"`
+		Email                   string   `json:"Email"`
+		DisplayName             string   `json:"DisplayName"`
+		GroupIds                []string `json:"GroupIds"`
+		AuthenticationMethod    string   `json:"AuthenticationMethod"`
+		CertificateValidityDays int      `json:"CertificateValidityDays"`
+		Expiration              string   `json:"Expiration"`
+		Enabled                 bool     `json:"Enabled"`
+	}
+	if err := json.Unmarshal(responseBody, &user); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("username", user.Username)
+	d.Set("email", user.Email)
+	d.Set("display_name", user.DisplayName)
+	d.Set("group_ids", user.GroupIds)
+	d.Set("authentication_method", user.AuthenticationMethod)
+	d.Set("certificate_validity_days", user.CertificateValidityDays)
+	d.Set("expiration", user.Expiration)
+	d.Set("enabled", user.Enabled)
+
+	return nil
+}
+
+func resourceLocalUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := localUserPayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/users/"+d.Id(), payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceLocalUserRead(ctx, d, m)
+}
+
+func resourceLocalUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/users/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceMabBypassException manages a temporary MAB bypass exception: a MAC address that is allowed on the network
+// regardless of policy for a bounded time window, with the justification recorded, for break-glass access that
+// must not end up in the permanent whitelist
+func ResourceMabBypassException() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMabBypassExceptionCreate,
+		ReadContext:   resourceMabBypassExceptionRead,
+		UpdateContext: resourceMabBypassExceptionUpdate,
+		DeleteContext: resourceMabBypassExceptionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the bypass exception", ResourceMabBypassException),
+		},
+		Schema: map[string]*schema.Schema{
+			"mac_address": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringMatch(macAddressPattern, macAddressMessage),
+				StateFunc:        normalizeMacAddressState,
+				DiffSuppressFunc: suppressEquivalentMacAddress,
+				Description:      "The MAC address allowed regardless of policy.",
+			},
+			"justification": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Why the exception is needed, e.g. an incident or change ticket. Recorded in the Portnox audit log.",
+			},
+			"start_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The time the exception takes effect, in RFC 3339 format. Takes effect immediately when not set.",
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "The time the exception expires, in RFC 3339 format.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the exception: pending before start_time, active, or expired after end_time.",
+			},
+			"created_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The administrator or API key that created the exception.",
+			},
+		},
+	}
+}
+
+// mabBypassExceptionPayload builds the API representation of the bypass exception from the resource data, and
+// checks that the window ends after it starts and has not already ended
+func mabBypassExceptionPayload(d *schema.ResourceData) (map[string]interface{}, diag.Diagnostics) {
+	endTime, _ := time.Parse(time.RFC3339, d.Get("end_time").(string))
+	if !endTime.After(time.Now()) {
+		return nil, diag.Errorf("end_time must be in the future")
+	}
+
+	payload := map[string]interface{}{
+		"MacAddress":    d.Get("mac_address").(string),
+		"Justification": d.Get("justification").(string),
+		"EndTime":       d.Get("end_time").(string),
+	}
+	if startTime, ok := d.GetOk("start_time"); ok {
+		start, _ := time.Parse(time.RFC3339, startTime.(string))
+		if !endTime.After(start) {
+			return nil, diag.Errorf("end_time must be after start_time")
+		}
+		payload["StartTime"] = startTime.(string)
+	}
+
+	return payload, nil
+}
+
+// setBypassExceptionTime sets a time attribute from the API, keeping the configured value when both denote the same
+// instant, since the API may return times in another time zone or with fractional seconds
+func setBypassExceptionTime(d *schema.ResourceData, attribute, value string) {
+	current, err := time.Parse(time.RFC3339, d.Get(attribute).(string))
+	if err == nil {
+		if returned, err := time.Parse(time.RFC3339, value); err == nil && returned.Equal(current) {
+			return
+		}
+	}
+	d.Set(attribute, value)
+}
+
+func resourceMabBypassExceptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := mabBypassExceptionPayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/mab-bypass-exceptions", payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "mac_address")
+	}
+
+	var exception struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &exception); err != nil {
+		return diag.FromErr(err)
+	}
+	if exception.Id == "" {
+		return diag.Errorf("the API did not return an ID for the bypass exception of %s", d.Get("mac_address").(string))
+	}
+
+	d.SetId(exception.Id)
+
+	return resourceMabBypassExceptionRead(ctx, d, m)
+}
+
+func resourceMabBypassExceptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mab-bypass-exceptions/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			// Portnox purges exceptions some time after they expire. Recreating one would reopen a closed
+			// break-glass window, so an expired exception stays in state until it is removed from the configuration.
+			endTime, parseErr := time.Parse(time.RFC3339, d.Get("end_time").(string))
+			if parseErr == nil && !endTime.After(time.Now()) {
+				log.Printf("[DEBUG] Bypass exception %s expired at %s and was purged, keeping it in state as expired", d.Id(), endTime.Format(time.RFC3339))
+				d.Set("status", "expired")
+				return nil
+			}
+			return removeFromState(d, "portnox_mab_bypass_exception", "bypass exception not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var exception struct {
+		MacAddress    string `json:"MacAddress"`
+		Justification string `json:"Justification"`
+		StartTime     string `json:"StartTime"`
+		EndTime       string `json:"EndTime"`
+		Status        string `json:"Status"`
+		CreatedBy     string `json:"CreatedBy"`
+	}
+	if err := json.Unmarshal(responseBody, &exception); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Keep the notation of the configuration when the API returns the MAC address in another one
+	current, _ := macHex(d.Get("mac_address").(string))
+	if returned, _ := macHex(exception.MacAddress); returned != current {
+		d.Set("mac_address", exception.MacAddress)
+	}
+	d.Set("justification", exception.Justification)
+	setBypassExceptionTime(d, "start_time", exception.StartTime)
+	setBypassExceptionTime(d, "end_time", exception.EndTime)
+	d.Set("status", exception.Status)
+	d.Set("created_by", exception.CreatedBy)
+
+	return nil
+}
+
+func resourceMabBypassExceptionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := mabBypassExceptionPayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/mab-bypass-exceptions/"+d.Id(), payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceMabBypassExceptionRead(ctx, d, m)
+}
+
+func resourceMabBypassExceptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/mab-bypass-exceptions/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
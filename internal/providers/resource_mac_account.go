@@ -3,11 +3,16 @@ package providers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
 
 	"github.com/portnox-community/terraform-provider-portnox/common"
+	"github.com/portnox-community/terraform-provider-portnox/internal/whitelistdiff"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -15,13 +20,30 @@ func ResourceMacAccount() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceMacAccountCreate,
 		ReadContext:   resourceMacAccountRead,
+		UpdateContext: resourceMacAccountUpdate,
 		DeleteContext: resourceMacAccountDelete,
+		CustomizeDiff: customdiff.All(customizeDiffTagsAll, customizeDiffVendorsWhitelist),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMacAccountImport,
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceMacAccountV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceMacAccountStateUpgradeV0,
+				Version: 0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"account_name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "The name of the MAC-based account.",
-				ForceNew:    true,
+				Description: "The name of the MAC-based account. Changing it renames the account in place, keeping its whitelist.",
+			},
+			"account_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the MAC-based account. Unlike the name, it does not change when the account is renamed.",
 			},
 			"block_reason": {
 				Type:        schema.TypeString,
@@ -37,7 +59,8 @@ func ResourceMacAccount() *schema.Resource {
 			},
 			"description": {
 				Type:        schema.TypeString,
-				Computed:    true, // Make this field read-only
+				Optional:    true,
+				Computed:    true,
 				Description: "A description of the MAC-based account.",
 			},
 			"group_id": {
@@ -54,9 +77,9 @@ func ResourceMacAccount() *schema.Resource {
 			},
 			"is_block_by_admin": {
 				Type:        schema.TypeBool,
+				Optional:    true,
 				Computed:    true,
-				Description: "Indicates if the account is blocked by an admin.",
-				ForceNew:    false,
+				Description: "Indicates if the account is blocked by an admin. Setting it blocks or unblocks the account in place.",
 			},
 			"org_id": {
 				Type:        schema.TypeString,
@@ -69,11 +92,19 @@ func ResourceMacAccount() *schema.Resource {
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"mac": {
+						"mac_address": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
+							Computed:    true,
 							Description: "The MAC address.",
 						},
+						"mac": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The MAC address. Deprecated: use mac_address instead.",
+							Deprecated:  "Use mac_address instead. The mac attribute will be removed in a future major release.",
+						},
 						"description": {
 							Type:        schema.TypeString,
 							Optional:    true,
@@ -95,7 +126,7 @@ func ResourceMacAccount() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "A list of vendor names in the whitelist.",
+				Description: "A list of vendor names in the whitelist. Names are validated against the Portnox vendor catalog at plan time.",
 				ForceNew:    true, // Set ForceNew to true
 			},
 			"put_devices_into_voice_vlan": {
@@ -105,11 +136,34 @@ func ResourceMacAccount() *schema.Resource {
 				ForceNew:    true, // Set ForceNew to true
 			},
 			"identity_pre_shared_key": {
-				Type:        schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"psk_wo"},
+				Description:   "The identity pre-shared key. Changing the key rotates it in place. The value is stored in the state file; use psk_wo to keep it out of state.",
+			},
+			"psk_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"identity_pre_shared_key"},
+				Description:   "The identity pre-shared key as a write-only argument, which is never stored in state. Requires Terraform 1.11 or later. The key is only sent on create and when psk_version changes.",
+			},
+			"psk_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"psk_wo"},
+				Description:  "A version number for psk_wo. Change it to send the current psk_wo value and rotate the key.",
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "The identity pre-shared key.",
-				ForceNew:    true, // Set ForceNew to true
+				Default:     false,
+				Description: "Allows the account to be destroyed while its whitelist still has entries. When false, destroying an account with whitelist entries fails, so its devices are not de-authorized by accident.",
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -120,15 +174,28 @@ func resourceMacAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 	accountName := d.Get("account_name").(string)
 
 	description := d.Get("description").(string)
-	account := map[string]string{
+	account := map[string]interface{}{
 		"AccountName": d.Get("account_name").(string),
 	}
 	if description != "" {
 		account["Description"] = description
 	}
+	if blocked, ok := d.GetOk("is_block_by_admin"); ok {
+		account["IsBlockByAdmin"] = blocked.(bool)
+	}
+	if tags := mergedTags(config, d.Get("tags").(map[string]interface{})); len(tags) > 0 {
+		account["Tags"] = tags
+	}
+	psk, _, diags := changedSecret(d, "identity_pre_shared_key", "psk_wo", "psk_version")
+	if diags.HasError() {
+		return diags
+	}
+	if psk != "" {
+		account["IdentityPreSharedKey"] = psk
+	}
 
 	payload := map[string]interface{}{
-		"MacBasedAccounts": []map[string]string{account},
+		"MacBasedAccounts": []map[string]interface{}{account},
 	}
 
 	// Process `mac_whitelist` blocks dynamically
@@ -137,8 +204,16 @@ func resourceMacAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 		whitelistEntries := make([]map[string]interface{}, len(macWhitelist))
 		for i, entry := range macWhitelist {
 			entryMap := entry.(map[string]interface{})
+			// Prefer mac_address and fall back to the deprecated mac attribute
+			macAddress, _ := entryMap["mac_address"].(string)
+			if macAddress == "" {
+				macAddress, _ = entryMap["mac"].(string)
+			}
+			if macAddress == "" {
+				return diag.Errorf("mac_whitelist entry %d: mac_address must be set", i)
+			}
 			whitelistEntries[i] = map[string]interface{}{
-				"Mac":         entryMap["mac"],
+				"Mac":         macAddress,
 				"Description": entryMap["description"],
 				"Expiration":  entryMap["expiration"],
 			}
@@ -149,8 +224,8 @@ func resourceMacAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 	// Ensure the POST request uses the base URL for the API endpoint
 	endpoint := "/api/mac-based-accounts"
 
-	if _, err := config.MakeRequestWithRetry("POST", endpoint, payload); err != nil {
-		return diag.FromErr(err)
+	if _, err := config.MakeRequestWithRetry(ctx, "POST", endpoint, payload); err != nil {
+		return apiErrorDiagnostics(err, "account_name")
 	}
 
 	d.SetId(accountName)
@@ -162,45 +237,29 @@ func resourceMacAccountRead(ctx context.Context, d *schema.ResourceData, m inter
 	config := m.(*common.Config)
 	accountID := d.Id()
 
-	responseBody, err := config.MakeRequestWithRetry("GET", "/api/mac-based-accounts/"+accountID, nil)
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+accountID, nil)
 	if err != nil {
-		// Attempt to parse the response body for specific error details
-		var errorResponse struct {
-			InternalErrorCode int    `json:"InternalErrorCode"`
-			InternalError     string `json:"InternalError"`
-		}
-		if parseErr := json.Unmarshal(responseBody, &errorResponse); parseErr == nil {
-			if errorResponse.InternalErrorCode == 5357 {
-				log.Printf("[DEBUG] Account not found: %s", errorResponse.InternalError)
-				log.Printf("[DEBUG] Clearing state for resource ID: %s", accountID)
-				d.SetId("") // Clear the state to trigger recreation
-				return diag.Diagnostics{
-					diag.Diagnostic{
-						Severity: diag.Warning,
-						Summary:  "Resource not found",
-						Detail:   "The resource is missing from the API and will be recreated on the next apply.",
-					},
-				} // Return a warning diagnostic to signal Terraform to recreate the resource
-			}
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_mac_account", "account not found")
 		}
 
-		// If parsing fails or the error is not specific, return the original error
-		return diag.FromErr(err)
+		// If the error is not a not-found response, return the original error
+		return apiErrorDiagnostics(err, "")
 	}
 
-	log.Printf("[DEBUG] Account read response: %s", string(responseBody))
+	log.Printf("[DEBUG] Account read response: %s", common.RedactSecrets(string(responseBody), config.APIKey))
 
 	// Parse JSON and populate Terraform state
 	var account struct {
 		AccountId        string `json:"AccountId"`
 		AccountName      string `json:"AccountName"`
+		Description      string `json:"Description"`
+		IsBlockByAdmin   bool   `json:"IsBlockByAdmin"`
+		BlockReason      string `json:"BlockReason"`
 		AgentlessOptions struct {
-			MacWhiteList []struct {
-				Mac         string `json:"Mac"`
-				Description string `json:"Description"`
-				Expiration  string `json:"Expiration"`
-			} `json:"MacWhiteList"`
+			MacWhiteList common.MacWhiteList `json:"MacWhiteList"`
 		} `json:"AgentlessOptions"`
+		Tags map[string]string `json:"Tags"`
 		// Add other fields as needed...
 	}
 
@@ -210,39 +269,241 @@ func resourceMacAccountRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	d.Set("account_id", account.AccountId)
 	d.Set("account_name", account.AccountName)
+	d.Set("description", account.Description)
+	d.Set("is_block_by_admin", account.IsBlockByAdmin)
+	d.Set("block_reason", account.BlockReason)
+	setTagsFromAPI(config, d, account.Tags)
 	// d.Set(...) for other fields
 
-	// Ensure `mac_whitelist` is only set in the state if explicitly defined in the configuration
-	if _, ok := d.GetOk("mac_whitelist"); ok {
-		// Parse `mac_whitelist` blocks dynamically from the API response
-		if len(account.AgentlessOptions.MacWhiteList) > 0 {
-			whitelistEntries := make([]map[string]interface{}, len(account.AgentlessOptions.MacWhiteList))
-			for i, entry := range account.AgentlessOptions.MacWhiteList {
-				whitelistEntries[i] = map[string]interface{}{
-					"mac":         entry.Mac,
-					"description": entry.Description,
-					"expiration":  entry.Expiration,
-				}
-			}
-			d.Set("mac_whitelist", whitelistEntries)
-		} else {
-			d.Set("mac_whitelist", nil)
+	// Refresh only the `mac_whitelist` entries this account manages, so entries added by whitelist resources or
+	// outside of Terraform are not taken over, and still block a destroy without force_destroy
+	managed := accountWhitelistMacs(d)
+	whitelistEntries := make([]map[string]interface{}, 0, len(managed))
+	for _, entry := range macWhitelistEntries(account.AgentlessOptions.MacWhiteList.Entries) {
+		if digits, ok := macHex(entry["mac_address"].(string)); ok && managed[digits] {
+			whitelistEntries = append(whitelistEntries, entry)
 		}
-	} else {
-		// Clear `mac_whitelist` from the state if not explicitly defined
-		d.Set("mac_whitelist", nil)
+	}
+	if err := d.Set("mac_whitelist", whitelistEntries); err != nil {
+		return diag.Errorf("error setting mac_whitelist: %s", err)
 	}
 
 	return nil
 }
 
+// macWhitelistEntries converts the whitelist entries of an account into mac_whitelist blocks
+func macWhitelistEntries(entries []map[string]interface{}) []map[string]interface{} {
+	whitelistEntries := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		mac, _ := entry["Mac"].(string)
+		description, _ := entry["Description"].(string)
+		expiration, _ := entry["Expiration"].(string)
+		whitelistEntries = append(whitelistEntries, map[string]interface{}{
+			"mac_address": mac,
+			"mac":         mac,
+			"description": description,
+			"expiration":  expiration,
+		})
+	}
+	return whitelistEntries
+}
+
+// accountWhitelistMacs returns the hex digits of the MAC addresses in the mac_whitelist of the account, which are
+// the whitelist entries it manages
+func accountWhitelistMacs(d *schema.ResourceData) map[string]bool {
+	macs := make(map[string]bool)
+	for _, entry := range d.Get("mac_whitelist").([]interface{}) {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		macAddress, _ := entryMap["mac_address"].(string)
+		if macAddress == "" {
+			macAddress, _ = entryMap["mac"].(string)
+		}
+		if digits, ok := macHex(macAddress); ok {
+			macs[digits] = true
+		}
+	}
+	return macs
+}
+
+// resourceMacAccountImport imports an account by name. The arguments that are only sent when the account is created,
+// and so are not refreshed by the read, are set from the account so the imported state matches it.
+func resourceMacAccountImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := importStateID("the name of the MAC-based account", ResourceMacAccount)(ctx, d, m); err != nil {
+		return nil, err
+	}
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+d.Id(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving MAC account %s: %s", d.Id(), err)
+	}
+
+	var account struct {
+		GroupId          string `json:"GroupId"`
+		AgentlessOptions struct {
+			VendorsWhiteList []struct {
+				VendorName string `json:"VendorName"`
+			} `json:"VendorsWhiteList"`
+		} `json:"AgentlessOptions"`
+	}
+	if err := json.Unmarshal(responseBody, &account); err != nil {
+		return nil, fmt.Errorf("error parsing API response: %s", err)
+	}
+
+	d.Set("force_destroy", false)
+	d.Set("group_id", account.GroupId)
+	// The whitelist entries are not imported into mac_whitelist: they may belong to whitelist resources, and entries
+	// managed by the account are not protected by force_destroy
+	if len(account.AgentlessOptions.VendorsWhiteList) > 0 {
+		vendors := make([]string, 0, len(account.AgentlessOptions.VendorsWhiteList))
+		for _, vendor := range account.AgentlessOptions.VendorsWhiteList {
+			vendors = append(vendors, vendor.VendorName)
+		}
+		d.Set("vendors_whitelist", vendors)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceMacAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+	accountID := d.Id()
+
+	payload := map[string]interface{}{}
+	attribute := ""
+	// The account is renamed in place, so its whitelist and the entries managed by other resources stay with it
+	if d.HasChange("account_name") {
+		payload["AccountName"] = d.Get("account_name").(string)
+		attribute = "account_name"
+	}
+	if d.HasChange("description") {
+		payload["Description"] = d.Get("description").(string)
+		attribute = "description"
+	}
+	if d.HasChange("is_block_by_admin") {
+		payload["IsBlockByAdmin"] = d.Get("is_block_by_admin").(bool)
+		attribute = "is_block_by_admin"
+	}
+	if d.HasChanges("tags", "tags_all") {
+		payload["Tags"] = mergedTags(config, d.Get("tags").(map[string]interface{}))
+		attribute = "tags"
+	}
+
+	// The key is rotated in place, either from the stored attribute or from the write-only argument when its version changes
+	psk, changed, diags := changedSecret(d, "identity_pre_shared_key", "psk_wo", "psk_version")
+	if diags.HasError() {
+		return diags
+	}
+	if changed && psk != "" {
+		payload["IdentityPreSharedKey"] = psk
+		attribute = "identity_pre_shared_key"
+	}
+
+	if len(payload) > 0 {
+		if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/mac-based-accounts/"+accountID, payload); err != nil {
+			return apiErrorDiagnostics(err, attribute)
+		}
+	}
+
+	// The account is identified by its name, so the ID follows a rename
+	if d.HasChange("account_name") {
+		d.SetId(d.Get("account_name").(string))
+	}
+
+	if d.HasChange("mac_whitelist") {
+		failures, err := updateAccountMacWhitelist(ctx, config, d)
+		if err != nil {
+			return apiErrorDiagnostics(err, "mac_whitelist")
+		}
+		diags = append(diags, whitelistFailureDiagnostics(failures, "mac_whitelist")...)
+	}
+
+	return append(diags, resourceMacAccountRead(ctx, d, m)...)
+}
+
+// macWhitelistByMac returns mac_whitelist blocks keyed by MAC address in the canonical notation, taking the MAC
+// address from the deprecated mac attribute when mac_address is not set
+func macWhitelistByMac(entries []interface{}) map[string]map[string]interface{} {
+	byMac := make(map[string]map[string]interface{}, len(entries))
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		macAddress, _ := entryMap["mac_address"].(string)
+		if macAddress == "" {
+			macAddress, _ = entryMap["mac"].(string)
+		}
+		if macAddress == "" {
+			continue
+		}
+		macAddress = normalizeMacAddress(macAddress)
+		byMac[macAddress] = map[string]interface{}{
+			"mac_address": macAddress,
+			"description": entryMap["description"],
+			"expiration":  entryMap["expiration"],
+		}
+	}
+	return byMac
+}
+
+// updateAccountMacWhitelist sends the changes to the mac_whitelist of the account, and returns the entries the API
+// rejected, mapped to the reason
+func updateAccountMacWhitelist(ctx context.Context, config *common.Config, d *schema.ResourceData) (map[string]string, error) {
+	old, new := d.GetChange("mac_whitelist")
+	adds, removes, updates := whitelistdiff.ComputeDelta(macWhitelistByMac(old.([]interface{})), macWhitelistByMac(new.([]interface{})))
+
+	// A changed entry is removed and added again, unless the tenant updates entries in place
+	if !config.WhitelistUpsert() {
+		removes = append(removes, updates...)
+	}
+	remove := make([]map[string]interface{}, 0, len(removes))
+	for _, entry := range removes {
+		remove = append(remove, whitelistEntry(entry))
+	}
+	add := make([]map[string]interface{}, 0, len(adds)+len(updates))
+	for _, entry := range append(adds, updates...) {
+		add = append(add, whitelistEntry(entry))
+	}
+
+	return sendMacWhitelistChanges(ctx, config, d.Get("account_name").(string), remove, add)
+}
+
 func resourceMacAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(*common.Config)
 
 	accountID := d.Id()
 
-	if _, err := config.MakeRequestWithRetry("DELETE", "/api/mac-based-accounts/"+accountID, nil); err != nil {
-		return diag.FromErr(err)
+	// Whitelist resources that reference the account are destroyed before it, so apart from the entries of its own
+	// mac_whitelist, the entries left are not managed by this configuration, and deleting the account would
+	// de-authorize their devices
+	if !d.Get("force_destroy").(bool) {
+		macs, err := whitelistMacs(ctx, config, accountID)
+		if err != nil {
+			if config.IsNotFoundError(err) {
+				d.SetId("")
+				return nil
+			}
+			return apiErrorDiagnostics(err, "")
+		}
+		managed := accountWhitelistMacs(d)
+		remaining := make([]string, 0, len(macs))
+		for digits := range macs {
+			if !managed[digits] {
+				remaining = append(remaining, formatMacAddress(digits, "colon", false))
+			}
+		}
+		if len(remaining) > 0 {
+			sort.Strings(remaining)
+			return diag.Errorf("account %s still has %d whitelist entries (%s); remove them or set force_destroy = true to destroy the account and de-authorize its devices", accountID, len(remaining), strings.Join(remaining, ", "))
+		}
+	}
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/mac-based-accounts/"+accountID, nil); err != nil {
+		return apiErrorDiagnostics(err, "")
 	}
 
 	d.SetId("")
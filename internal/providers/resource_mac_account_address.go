@@ -2,23 +2,41 @@ package providers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/portnox-community/terraform-provider-portnox/common"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceMacAccountAddress() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceMacAccountAddressCreate,
 		ReadContext:   resourceMacAccountAddressRead,
+		UpdateContext: resourceMacAccountAddressUpdate,
 		DeleteContext: resourceMacAccountAddressDelete,
+		CustomizeDiff: customizeDiffAccountName,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMacAccountAddressImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"account_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"account_name", "account_id"},
+				Description:  "The name of the MAC-based account. Set when account_id is used. Changing it follows a rename of the account in place, and replaces the resource when it names another existing account.",
+			},
+			"account_id": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the MAC-based account.",
-				ForceNew:    true, // Ensure changes trigger recreation
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the MAC-based account, e.g. the account_id of a portnox_mac_account resource. Unlike the name, the ID does not change when the account is renamed.",
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -26,11 +44,28 @@ func ResourceMacAccountAddress() *schema.Resource {
 				Description: "A description of the MAC address.",
 				ForceNew:    true, // Ensure changes trigger recreation
 			},
+			"owner": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(whitelistMetadataPattern, "owner must contain only alphanumeric characters, dots, underscores, or dashes"),
+				Description:  "The person or team responsible for the device. Stored in the API description after the description.",
+			},
+			"ticket": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(whitelistMetadataPattern, "ticket must contain only alphanumeric characters, dots, underscores, or dashes"),
+				Description:  "The reference of the ticket the device was approved in. Stored in the API description after the description and owner.",
+			},
 			"mac_address": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The MAC address to be added to the whitelist.",
-				ForceNew:    true, // Ensure changes trigger recreation
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The MAC address to be added to the whitelist.",
+				ForceNew:         true, // Ensure changes trigger recreation
+				ValidateFunc:     validation.StringMatch(macAddressPattern, macAddressMessage),
+				StateFunc:        normalizeMacAddressState,
+				DiffSuppressFunc: suppressEquivalentMacAddress,
 			},
 			"expiration": {
 				Type:        schema.TypeString,
@@ -45,30 +80,34 @@ func ResourceMacAccountAddress() *schema.Resource {
 func resourceMacAccountAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(*common.Config)
 
-	accountName := d.Get("account_name").(string)
+	accountName, err := macAccountName(ctx, config, d)
+	if err != nil {
+		return apiErrorDiagnostics(err, "account_id")
+	}
 	macAddress := d.Get("mac_address").(string)
-	description := d.Get("description").(string)
+	description := encodeWhitelistDescription(d.Get("description").(string), d.Get("owner").(string), d.Get("ticket").(string))
 	expiration := d.Get("expiration").(string)
 
-	payload := map[string]interface{}{
-		"AccountName": accountName,
-		"MacWhiteList": []map[string]interface{}{
-			{
-				"Description": description,
-				"Mac":         macAddress,
-			},
-		},
+	if len(description) > maxWhitelistDescriptionLength {
+		return diag.Errorf("the description, owner, and ticket of %s are stored together as %q, which is longer than %d characters", macAddress, description, maxWhitelistDescriptionLength)
 	}
 
-	// Add expiration to the payload only if it is specified
-	if expiration != "" {
-		payload["MacWhiteList"].([]map[string]interface{})[0]["Expiration"] = expiration
+	entry := map[string]interface{}{
+		"Description": description,
+		"Mac":         macAddress,
 	}
 
-	endpoint := "/api/mac-based-accounts/mac-whitelist-add"
+	// Add expiration to the entry only if it is specified
+	if expiration != "" {
+		entry["Expiration"] = expiration
+	}
 
-	if _, err := config.MakeRequestWithRetry("POST", endpoint, payload); err != nil {
-		return diag.FromErr(err)
+	// Adds for the same account from concurrently created resources are coalesced into one request
+	if err := config.AddToWhitelist(ctx, accountName, entry); err != nil {
+		return apiErrorDiagnostics(err, "mac_address")
+	}
+	if err := verifyWhitelistWrites(ctx, config, accountName, []map[string]interface{}{entry}, nil); err != nil {
+		return apiErrorDiagnostics(err, "mac_address")
 	}
 
 	d.SetId(accountName + ":" + macAddress)
@@ -79,41 +118,82 @@ func resourceMacAccountAddressCreate(ctx context.Context, d *schema.ResourceData
 func resourceMacAccountAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(*common.Config)
 
+	accountName, err := macAccountName(ctx, config, d)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_mac_account_address", fmt.Sprintf("account %s not found", d.Get("account_id").(string)))
+		}
+		return apiErrorDiagnostics(err, "")
+	}
 	macAddress := d.Get("mac_address").(string)
 	description := d.Get("description").(string)
 	expiration := d.Get("expiration").(string)
 
-	payload := map[string]interface{}{
-		"MacWhiteList": []map[string]interface{}{
-			{
-				"Description": description,
-				"Mac":         macAddress,
-				"Expiration":  expiration,
-			},
-		},
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+accountName, nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_mac_account_address", fmt.Sprintf("account %s not found", accountName))
+		}
+		return apiErrorDiagnostics(err, "")
 	}
 
-	endpoint := "/api/mac-based-accounts/search"
-
-	_, err := config.MakeRequestWithRetry("POST", endpoint, payload)
-	if err != nil {
+	var account common.MacAccount
+	if err := json.Unmarshal(responseBody, &account); err != nil {
 		return diag.FromErr(err)
 	}
 
+	found := false
+	var diags diag.Diagnostics
+	for _, macMap := range accountMacWhiteList(config, account) {
+		if mac, ok := macMap["Mac"].(string); ok && suppressEquivalentMacAddress("", mac, macAddress, nil) {
+			found = true
+			returnedExpiration, _ := macMap["Expiration"].(string)
+			diags = expiredEntriesWarning(accountName, []interface{}{map[string]interface{}{
+				"mac_address": macAddress,
+				"expiration":  returnedExpiration,
+			}}, time.Now())
+			break
+		}
+	}
+
+	if !found {
+		return removeFromState(d, "portnox_mac_account_address", fmt.Sprintf("MAC address %s not found in account %s", macAddress, accountName))
+	}
+
 	// Process the response and update the state
 	d.Set("description", description)
 	d.Set("mac_address", macAddress)
 	d.Set("expiration", expiration)
 
-	return nil
+	return diags
+}
+
+// resourceMacAccountAddressUpdate follows a rename of the account, the only change made in place. The entry stays in
+// the whitelist of the renamed account, so only the ID, which includes the account name, changes.
+func resourceMacAccountAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+	if err := checkAccountRenamed(ctx, config, d); err != nil {
+		return apiErrorDiagnostics(err, "account_name")
+	}
+
+	d.SetId(d.Get("account_name").(string) + ":" + d.Get("mac_address").(string))
+
+	return resourceMacAccountAddressRead(ctx, d, m)
 }
 
 func resourceMacAccountAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(*common.Config)
 
-	accountName := d.Get("account_name").(string)
+	accountName, err := macAccountName(ctx, config, d)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return apiErrorDiagnostics(err, "")
+	}
 	macAddress := d.Get("mac_address").(string)
-	description := d.Get("description").(string)
+	description := encodeWhitelistDescription(d.Get("description").(string), d.Get("owner").(string), d.Get("ticket").(string))
 	expiration := d.Get("expiration").(string)
 
 	payload := map[string]interface{}{
@@ -133,11 +213,62 @@ func resourceMacAccountAddressDelete(ctx context.Context, d *schema.ResourceData
 
 	endpoint := "/api/mac-based-accounts/mac-whitelist-remove"
 
-	if _, err := config.MakeRequestWithRetry("DELETE", endpoint, payload); err != nil {
-		return diag.FromErr(err)
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", endpoint, payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+	if err := verifyWhitelistWrites(ctx, config, accountName, nil, []string{macAddress}); err != nil {
+		return apiErrorDiagnostics(err, "")
 	}
 
 	d.SetId("")
 
 	return nil
 }
+
+// resourceMacAccountAddressImport imports a whitelist entry by <account>:<mac>, the ID the resource is created with.
+// The description, owner, ticket, and expiration are taken from the entry, as the read keeps the configured values.
+func resourceMacAccountAddressImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	config := m.(*common.Config)
+
+	// A MAC address in colon notation contains colons itself, so it is taken from the end of the ID
+	id := strings.TrimSpace(d.Id())
+	const macLength = len("00:00:00:00:00:00")
+	var accountName, macAddress string
+	if len(id) >= macLength+2 && id[len(id)-macLength-1] == ':' && macAddressPattern.MatchString(id[len(id)-macLength:]) {
+		accountName, macAddress = id[:len(id)-macLength-1], id[len(id)-macLength:]
+	} else if separator := strings.LastIndex(id, ":"); separator > 0 && macAddressPattern.MatchString(id[separator+1:]) {
+		accountName, macAddress = id[:separator], id[separator+1:]
+	} else {
+		return nil, fmt.Errorf("invalid import ID %q, expected <account>:<mac>, e.g. printers:00:11:22:33:44:55 or printers:0011.2233.4455", d.Id())
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+accountName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving MAC account %s: %s", accountName, err)
+	}
+	var account common.MacAccount
+	if err := json.Unmarshal(responseBody, &account); err != nil {
+		return nil, fmt.Errorf("error parsing API response: %s", err)
+	}
+
+	for _, macMap := range accountMacWhiteList(config, account) {
+		mac, _ := macMap["Mac"].(string)
+		if !macMatchesPrefix(mac, macAddress) {
+			continue
+		}
+		apiDescription, _ := macMap["Description"].(string)
+		description, owner, ticket := decodeWhitelistDescription(apiDescription)
+		expiration, _ := macMap["Expiration"].(string)
+
+		d.SetId(accountName + ":" + mac)
+		d.Set("account_name", accountName)
+		d.Set("mac_address", mac)
+		d.Set("description", description)
+		d.Set("owner", owner)
+		d.Set("ticket", ticket)
+		d.Set("expiration", expiration)
+		return []*schema.ResourceData{d}, nil
+	}
+
+	return nil, fmt.Errorf("MAC address %s not found in account %s", macAddress, accountName)
+}
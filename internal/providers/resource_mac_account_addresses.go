@@ -2,16 +2,20 @@ package providers
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"log"
-	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/portnox-community/terraform-provider-portnox/common"
+	"github.com/portnox-community/terraform-provider-portnox/internal/whitelistdiff"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -22,48 +26,353 @@ func ResourceMacAccountAddresses() *schema.Resource {
 		ReadContext:   resourceMacAccountAddressesRead,
 		UpdateContext: resourceMacAccountAddressesUpdate,
 		DeleteContext: resourceMacAccountAddressesDelete,
+		CustomizeDiff: customdiff.All(
+			// An account created by auto_create_account is a new account, not a renamed one
+			customdiff.ForceNewIf("account_name", func(ctx context.Context, d *schema.ResourceDiff, m interface{}) bool {
+				return d.Id() != "" && d.HasChange("account_name") && d.Get("auto_create_account").(bool)
+			}),
+			customizeDiffAccountName,
+			customizeDiffPruneMacs,
+			customizeDiffMacConflicts,
+			customizeDiffMacDescriptions,
+			customizeDiffMacAddressesCSV,
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMacAccountAddressesImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"account_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"account_name", "account_id"},
+				Description:  "The name of the MAC-based account. Set when account_id is used. Changing it follows a rename of the account in place, and replaces the resource when it names another existing account.",
+			},
+			"account_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"auto_create_account"},
+				Description:   "The ID of the MAC-based account, e.g. the account_id of a portnox_mac_account resource. Unlike the name, the ID does not change when the account is renamed.",
+			},
+			"auto_create_account": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Create the MAC-based account when it does not exist yet, so no separate portnox_mac_account resource is needed. An account created this way is kept when the resource is destroyed.",
+			},
+			"account_group_id": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the MAC-based account.",
-				ForceNew:    true,
+				Optional:    true,
+				Description: "The ID of the group the account is created in when auto_create_account creates it. Has no effect on an existing account.",
 			},
 			"mac_addresses": {
-				Type:        schema.TypeList,
-				Required:    true,
-				Description: "A list of MAC addresses with descriptions.",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true, // Populated from mac_addresses_csv when the CSV input is used
+				ExactlyOneOf: []string{"mac_addresses", "mac_addresses_csv"},
+				Description:  "A list of MAC addresses with descriptions.",
 				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
 					"mac_address": {
-						Type:         schema.TypeString,
-						Required:     true,
-						Description:  "The MAC address to be added to the whitelist.",
-						ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$`), "must be a valid MAC address format (e.g., 00:00:00:00:00:00)"),
+						Type:             schema.TypeString,
+						Required:         true,
+						Description:      "The MAC address to be added to the whitelist.",
+						ValidateFunc:     validation.StringMatch(macAddressPattern, macAddressMessage),
+						StateFunc:        normalizeMacAddressState,
+						DiffSuppressFunc: suppressEquivalentMacAddress,
 					},
 					"description": {
 						Type:        schema.TypeString,
 						Optional:    true,
-						Description: "A description of the MAC address. Must be alphanumeric and maximum 64 characters.",
+						Description: "A description of the MAC address. Up to 64 letters, digits, spaces, dots, underscores, or dashes.",
 						ValidateFunc: validation.All(
-							validation.StringLenBetween(0, 64),
-							validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9-]*$`), "description must contain only alphanumeric characters or dashes and be up to 64 characters long"),
+							validation.StringLenBetween(0, maxWhitelistDescriptionLength),
+							validation.StringMatch(macDescriptionPattern, macDescriptionMessage),
 						),
 					},
+					"owner": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "The person or team responsible for the device. Stored in the API description after the description.",
+						ValidateFunc: validation.StringMatch(whitelistMetadataPattern, "owner must contain only alphanumeric characters, dots, underscores, or dashes"),
+					},
+					"ticket": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "The reference of the ticket the device was approved in. Stored in the API description after the description and owner.",
+						ValidateFunc: validation.StringMatch(whitelistMetadataPattern, "ticket must contain only alphanumeric characters, dots, underscores, or dashes"),
+					},
 					"expiration": {
 						Type:        schema.TypeString,
 						Optional:    true,
 						Description: "The expiration date/time of the MAC address.",
 					},
+					"vlan": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "A VLAN ID or name assigned to this device instead of the account VLAN.",
+					},
+					"voice": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Place this device on the voice VLAN, e.g. for IP phones.",
+					},
+					"created_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The time the MAC address was added to the whitelist.",
+					},
+					"created_by": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The administrator or API key that added the MAC address.",
+					},
+					"last_seen": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The time the device last connected, if it has connected.",
+					},
 				},
 				},
 			},
+			"mac_addresses_csv": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "An alternative to mac_addresses: CSV content with one mac,description,expiration,vlan,voice,owner,ticket row per MAC address. Only the mac column is required, and a header row is optional.",
+				ValidateFunc: validateMacAddressesCSV,
+			},
+			"mac_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of MAC addresses managed by this resource.",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of MAC addresses managed by this resource, including expired ones.",
+			},
+			"expiring_within_30d_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of managed MAC addresses whose expiration is within the next 30 days.",
+			},
+			"expired_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of managed MAC addresses whose expiration has passed.",
+			},
+			"prune_unseen_after": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDurationWithDays,
+				Description:  "Flag MAC addresses whose device has not connected within this duration (e.g. 90d) in stale_macs. Devices that never connected are flagged once they were added longer ago.",
+			},
+			"prune": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Default:      false,
+				RequiredWith: []string{"prune_unseen_after"},
+				Description:  "Remove the MAC addresses flagged in stale_macs from the whitelist on the next apply.",
+			},
+			"stale_macs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The managed MAC addresses whose device has not connected within prune_unseen_after.",
+			},
+			"pruned_macs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The configured MAC addresses removed from the whitelist by pruning. They are kept in state so the configuration does not re-add them, and can be deleted from the configuration at any time.",
+			},
+			"conflict_check": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "off",
+				ValidateFunc: validation.StringInSlice([]string{"off", "warn", "error"}, false),
+				Description:  "Check at plan time whether MAC addresses being added are already whitelisted in another account. One of off, warn to list them in conflicting_macs, or error to fail the plan.",
+			},
+			"conflicting_macs": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The MAC addresses being added that are already whitelisted in another account, mapped to that account name. Only set when conflict_check is warn.",
+			},
+			"unmanaged_macs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The MAC addresses in the account whitelist that are not managed by this resource, such as entries added outside of Terraform. They are reported only and left in place.",
+			},
+			"etag": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The revision of the account whitelist last seen by Terraform, if the API reports one. Updates are sent with If-Match so concurrent changes are detected.",
+			},
 		},
 	}
 }
 
+// parseMacAddressesCSV expands CSV content of mac,description,expiration,vlan,voice,owner,ticket rows into mac_addresses
+// entries. All columns but mac are optional, and a leading header row is skipped.
+func parseMacAddressesCSV(content string) ([]interface{}, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing mac_addresses_csv: %s", err)
+	}
+
+	macAddresses := make([]interface{}, 0, len(records))
+	for i, record := range records {
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+
+		// Skip the header row if present
+		if i == 0 {
+			header := strings.ToLower(strings.TrimSpace(record[0]))
+			if header == "mac" || header == "mac_address" {
+				continue
+			}
+		}
+
+		if len(record) > 7 {
+			return nil, fmt.Errorf("mac_addresses_csv line %d: expected at most 7 columns (mac,description,expiration,vlan,voice,owner,ticket), got %d", i+1, len(record))
+		}
+
+		entry := map[string]interface{}{
+			"mac_address": strings.TrimSpace(record[0]),
+			"description": "",
+			"expiration":  "",
+			"vlan":        "",
+			"voice":       false,
+			"owner":       "",
+			"ticket":      "",
+		}
+		if len(record) > 1 {
+			entry["description"] = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 {
+			entry["expiration"] = strings.TrimSpace(record[2])
+		}
+		if len(record) > 3 {
+			entry["vlan"] = strings.TrimSpace(record[3])
+		}
+		if len(record) > 4 && strings.TrimSpace(record[4]) != "" {
+			voice, err := strconv.ParseBool(strings.TrimSpace(record[4]))
+			if err != nil {
+				return nil, fmt.Errorf("mac_addresses_csv line %d: voice must be true or false, got %q", i+1, record[4])
+			}
+			entry["voice"] = voice
+		}
+		if len(record) > 5 {
+			entry["owner"] = strings.TrimSpace(record[5])
+		}
+		if len(record) > 6 {
+			entry["ticket"] = strings.TrimSpace(record[6])
+		}
+
+		if !macAddressPattern.MatchString(entry["mac_address"].(string)) {
+			return nil, fmt.Errorf("mac_addresses_csv line %d: %q %s", i+1, entry["mac_address"], macAddressMessage)
+		}
+		entry["mac_address"] = normalizeMacAddress(entry["mac_address"].(string))
+		if description := entry["description"].(string); len(description) > maxWhitelistDescriptionLength || !macDescriptionPattern.MatchString(description) {
+			return nil, fmt.Errorf("mac_addresses_csv line %d: %s", i+1, macDescriptionMessage)
+		}
+		for _, attribute := range []string{"owner", "ticket"} {
+			if !whitelistMetadataPattern.MatchString(entry[attribute].(string)) {
+				return nil, fmt.Errorf("mac_addresses_csv line %d: %s must contain only alphanumeric characters, dots, underscores, or dashes", i+1, attribute)
+			}
+		}
+		if err := checkWhitelistEntryDescription(entry); err != nil {
+			return nil, fmt.Errorf("mac_addresses_csv line %d: %s", i+1, err)
+		}
+
+		macAddresses = append(macAddresses, entry)
+	}
+
+	return macAddresses, nil
+}
+
+func validateMacAddressesCSV(v interface{}, k string) ([]string, []error) {
+	if _, err := parseMacAddressesCSV(v.(string)); err != nil {
+		return nil, []error{err}
+	}
+	return nil, nil
+}
+
+// customizeDiffMacAddressesCSV plans mac_addresses and the counts derived from it as changing when only
+// mac_addresses_csv changes, as the list is populated from the CSV during apply. It runs after the other checks,
+// which skip an unknown mac_addresses.
+func customizeDiffMacAddressesCSV(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("mac_addresses_csv") {
+		return nil
+	}
+	if d.NewValueKnown("mac_addresses_csv") && d.Get("mac_addresses_csv").(string) == "" {
+		return nil
+	}
+	for _, key := range []string{"mac_addresses", "mac_count", "total_count", "expiring_within_30d_count", "expired_count"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandMacAddresses returns the configured mac_addresses entries, expanding mac_addresses_csv if it is used instead
+func expandMacAddresses(d *schema.ResourceData) ([]interface{}, error) {
+	if csvContent, ok := d.GetOk("mac_addresses_csv"); ok {
+		return parseMacAddressesCSV(csvContent.(string))
+	}
+	if macAddresses, ok := d.GetOk("mac_addresses"); ok {
+		return macAddresses.([]interface{}), nil
+	}
+	return []interface{}{}, nil
+}
+
+// whitelistEntry converts a mac_addresses entry into its MacWhiteList API representation
+func whitelistEntry(macMap map[string]interface{}) map[string]interface{} {
+	entry := map[string]interface{}{
+		"Mac":         macMap["mac_address"].(string),
+		"Description": whitelistEntryDescription(macMap),
+	}
+	if expiration, ok := macMap["expiration"].(string); ok && expiration != "" {
+		entry["Expiration"] = expiration
+	}
+	if vlan, ok := macMap["vlan"].(string); ok && vlan != "" {
+		entry["Vlan"] = vlan
+	}
+	if voice, ok := macMap["voice"].(bool); ok && voice {
+		entry["VoiceVlan"] = true
+	}
+	return entry
+}
+
+// setWhitelistEntryAssignment copies the per-device VLAN assignment of an API whitelist item into a mac_addresses entry
+func setWhitelistEntryAssignment(entry map[string]interface{}, item map[string]interface{}) {
+	entry["vlan"] = ""
+	if vlan := item["Vlan"]; vlan != nil {
+		entry["vlan"] = fmt.Sprintf("%v", vlan)
+	}
+	voice, _ := item["VoiceVlan"].(bool)
+	entry["voice"] = voice
+}
+
+// setWhitelistEntryMetadata copies the computed metadata of an API whitelist item into an entry, leaving
+// fields the API does not report empty
+func setWhitelistEntryMetadata(entry map[string]interface{}, item map[string]interface{}) {
+	for attribute, key := range map[string]string{"created_at": "CreatedAt", "created_by": "CreatedBy", "last_seen": "LastSeen"} {
+		value, _ := item[key].(string)
+		entry[attribute] = value
+	}
+}
+
 // sortMacAddresses ensures consistent sorting of MAC addresses by mac_address first and then by description
 // This function is used across Create, Read, and Update methods to maintain consistent ordering
 func sortMacAddresses(macAddresses []interface{}) []interface{} {
@@ -117,9 +426,60 @@ func sortMacAddresses(macAddresses []interface{}) []interface{} {
 	return sortedInterfaces
 }
 
+// orderMacAddresses returns the entries in the order of the given MAC addresses, which is the order of the
+// configuration, followed by the entries not in that order sorted by MAC address
+func orderMacAddresses(order []string, entries map[string]map[string]interface{}) []interface{} {
+	ordered := make([]interface{}, 0, len(entries))
+	placed := make(map[string]bool, len(order))
+	for _, macAddress := range order {
+		if entry, exists := entries[macAddress]; exists && !placed[macAddress] {
+			ordered = append(ordered, entry)
+			placed[macAddress] = true
+		}
+	}
+
+	remaining := make([]interface{}, 0)
+	for macAddress, entry := range entries {
+		if !placed[macAddress] {
+			remaining = append(remaining, entry)
+		}
+	}
+	return append(ordered, sortMacAddresses(remaining)...)
+}
+
+// ensureMacAccount creates the MAC-based account when it does not exist, in the given group if one is set
+func ensureMacAccount(ctx context.Context, config *common.Config, accountName, groupID string) error {
+	_, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+accountName, nil)
+	if err == nil || !config.IsNotFoundError(err) {
+		return err
+	}
+
+	log.Printf("[INFO] portnox_mac_account_addresses: account %s not found, creating it", accountName)
+	account := map[string]interface{}{
+		"AccountName": accountName,
+	}
+	if groupID != "" {
+		account["GroupId"] = groupID
+	}
+	payload := map[string]interface{}{
+		"MacBasedAccounts": []map[string]interface{}{account},
+	}
+	_, err = config.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts", payload)
+	return err
+}
+
 func resourceMacAccountAddressesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(*common.Config)
-	accountName := d.Get("account_name").(string)
+	accountName, err := macAccountName(ctx, config, d)
+	if err != nil {
+		return apiErrorDiagnostics(err, "account_id")
+	}
+
+	if d.Get("auto_create_account").(bool) {
+		if err := ensureMacAccount(ctx, config, accountName, d.Get("account_group_id").(string)); err != nil {
+			return apiErrorDiagnostics(err, "auto_create_account")
+		}
+	}
 
 	payload := map[string]interface{}{
 		"AccountName":  accountName,
@@ -129,39 +489,58 @@ func resourceMacAccountAddressesCreate(ctx context.Context, d *schema.ResourceDa
 	// Store the original order of mac_addresses from the config
 	originalMacOrder := make([]string, 0)
 
-	if macAddresses, ok := d.GetOk("mac_addresses"); ok {
-		// Preserve the original order from configuration
-		for _, mac := range macAddresses.([]interface{}) {
-			macMap := mac.(map[string]interface{})
-			originalMacOrder = append(originalMacOrder, macMap["mac_address"].(string))
+	macAddresses, err := expandMacAddresses(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-			entry := map[string]interface{}{
-				"Mac":         macMap["mac_address"].(string),
-				"Description": macMap["description"].(string),
-			}
-			if expiration, ok := macMap["expiration"].(string); ok && expiration != "" {
-				entry["Expiration"] = expiration
-			}
-			payload["MacWhiteList"] = append(payload["MacWhiteList"].([]map[string]interface{}), entry)
-		}
+	// Preserve the original order from configuration
+	for _, mac := range macAddresses {
+		macMap := mac.(map[string]interface{})
+		originalMacOrder = append(originalMacOrder, macMap["mac_address"].(string))
+
+		payload["MacWhiteList"] = append(payload["MacWhiteList"].([]map[string]interface{}), whitelistEntry(macMap))
 	}
 	endpoint := "/api/mac-based-accounts/mac-whitelist-add"
-	if _, err := config.MakeRequestWithRetry("POST", endpoint, payload); err != nil {
-		return diag.FromErr(err)
+	responseBody, responseHeaders, err := config.MakeRequestWithRetryAndHeaders(ctx, "POST", endpoint, payload, nil)
+
+	// When the API rejects only some entries, keep the accepted ones in state and report the rejected ones
+	failures := common.WhitelistAddFailures(payload["MacWhiteList"].([]map[string]interface{}), responseBody, err)
+	if err != nil && failures == nil {
+		return apiErrorDiagnostics(err, "mac_addresses")
+	}
+	added := acceptedWhitelistEntries(payload["MacWhiteList"].([]map[string]interface{}), failures)
+	if len(added) == 0 && len(failures) > 0 {
+		return whitelistFailureDiagnostics(failures, "mac_addresses")
+	}
+	if err := verifyWhitelistWrites(ctx, config, accountName, added, nil); err != nil {
+		return apiErrorDiagnostics(err, "mac_addresses")
 	}
 	d.SetId(accountName)
+	d.Set("etag", responseHeaders.Get("ETag"))
 
-	// Keep the original order in the state - this is important to avoid unnecessary changes
-	if macAddresses, ok := d.GetOk("mac_addresses"); ok {
-		d.Set("mac_addresses", macAddresses)
+	rejected := make(map[string]bool, len(failures))
+	for mac := range failures {
+		rejected[mac] = true
 	}
+	macAddresses = withoutMacs(macAddresses, rejected)
 
-	return nil
+	// Keep the original order in the state - this is important to avoid unnecessary changes
+	d.Set("mac_addresses", macAddresses)
+	setWhitelistSummary(d, macAddresses)
+
+	return whitelistFailureDiagnostics(failures, "mac_addresses")
 }
 
 func resourceMacAccountAddressesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(*common.Config)
-	accountName := d.Get("account_name").(string)
+	accountName, err := macAccountName(ctx, config, d)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_mac_account_addresses", fmt.Sprintf("account %s not found", d.Get("account_id").(string)))
+		}
+		return apiErrorDiagnostics(err, "")
+	}
 
 	// Store the original order of mac_addresses from the config
 	originalMacOrder := make([]string, 0)
@@ -177,7 +556,7 @@ func resourceMacAccountAddressesRead(ctx context.Context, d *schema.ResourceData
 		for _, mac := range macs.([]interface{}) {
 			macMap := mac.(map[string]interface{})
 			entry := map[string]interface{}{
-				"Description": macMap["description"].(string),
+				"Description": whitelistEntryDescription(macMap),
 				"Mac":         macMap["mac_address"].(string),
 			}
 			if expiration, exists := macMap["expiration"].(string); exists && expiration != "" {
@@ -196,15 +575,19 @@ func resourceMacAccountAddressesRead(ctx context.Context, d *schema.ResourceData
 	// Fetch the current state from the API
 	endpoint := "/api/mac-based-accounts/search"
 
-	responseBytes, err := config.MakeRequestWithRetry("POST", endpoint, payload)
+	responseBytes, err := config.MakeRequestWithRetry(ctx, "POST", endpoint, payload)
 	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_mac_account_addresses", fmt.Sprintf("account %s not found", accountName))
+		}
+
 		// The search endpoint is known to return 400 with undocumented parameter requirements
 		// that vary by Portnox version/tenant. Rather than failing the plan, fall back to
 		// the existing Terraform state and emit a warning so the operator is informed.
 		log.Printf("[WARN] portnox_mac_account_addresses: Read for account '%s' failed (%s). "+
 			"Falling back to existing state — run apply to reconcile if needed.", accountName, err)
 		if responseBytes != nil {
-			log.Printf("[WARN] portnox_mac_account_addresses: API response body: %s", string(responseBytes))
+			log.Printf("[WARN] portnox_mac_account_addresses: API response body: %s", common.RedactSecrets(string(responseBytes), config.APIKey))
 		}
 		return diag.Diagnostics{{
 			Severity: diag.Warning,
@@ -213,80 +596,69 @@ func resourceMacAccountAddressesRead(ctx context.Context, d *schema.ResourceData
 		}}
 	}
 
-	// Unmarshal the response into a map
-	var response map[string]interface{}
+	var response common.MacAccountSearchResponse
 	if err := json.Unmarshal(responseBytes, &response); err != nil {
 		return diag.FromErr(err)
 	}
-	// Parse the response to extract MAC whitelist items
-	accounts := response["Accounts"].([]interface{})
-	if len(accounts) == 0 {
+	if len(response.Accounts) == 0 {
 		// Account no longer exists in Portnox — remove from Terraform state gracefully
-		log.Printf("[WARN] portnox_mac_account_addresses: account '%s' not found in Portnox, removing from state", accountName)
-		d.SetId("")
-		return nil
+		return removeFromState(d, "portnox_mac_account_addresses", fmt.Sprintf("account %s not found", accountName))
 	}
 
-	agentlessOptions := accounts[0].(map[string]interface{})["AgentlessOptions"].(map[string]interface{})
-
-	// Handle both API response formats - direct array or map with _items
-	var macWhiteList []interface{}
-	if macArray, ok := agentlessOptions["MacWhiteList"].([]interface{}); ok {
-		// If MacWhiteList is directly an array (newer API versions)
-		macWhiteList = macArray
-	} else if macMap, ok := agentlessOptions["MacWhiteList"].(map[string]interface{}); ok {
-		// If MacWhiteList is a map with _items (older API versions)
-		if items, ok := macMap["_items"].([]interface{}); ok {
-			macWhiteList = items
-		} else {
-			// Empty list as fallback
-			macWhiteList = []interface{}{}
-		}
-	} else {
-		// Empty list as fallback
-		macWhiteList = []interface{}{}
-	}
+	macWhiteList := accountMacWhiteList(config, response.Accounts[0])
 
 	// Prepare the list of MAC addresses to update the Terraform state
 	macAddresses = make([]map[string]interface{}, 0) // Use '=' to update the existing variable
 	// Filter MAC addresses to include only those defined in the current state or declared in the resource
-	stateMacs := make(map[string]bool)
+	// The API may return a MAC address in another notation than the configuration, so entries are matched on their
+	// hex digits and keep the notation of the state
+	stateMacs := make(map[string]string)
 	if macs, ok := d.GetOk("mac_addresses"); ok {
 		for _, mac := range macs.([]interface{}) {
 			macMap := mac.(map[string]interface{})
-			stateMacs[macMap["mac_address"].(string)] = true
+			if digits, ok := macHex(macMap["mac_address"].(string)); ok {
+				stateMacs[digits] = macMap["mac_address"].(string)
+			}
 		}
 	}
 
+	// Entries this resource does not manage are left in place and reported in unmanaged_macs
+	unmanagedMacs := make([]string, 0)
+
 	filteredMacAddresses := make([]map[string]interface{}, 0)
-	for _, mac := range macWhiteList {
-		if mac == nil {
-			continue
-		}
-		macMap := mac.(map[string]interface{})
-		macAddress := macMap["Mac"].(string)
-		if !stateMacs[macAddress] {
+	for _, macMap := range macWhiteList {
+		returnedMac, _ := macMap["Mac"].(string)
+		digits, _ := macHex(returnedMac)
+		macAddress, managed := stateMacs[digits]
+		if !managed {
+			if digits != "" {
+				unmanagedMacs = append(unmanagedMacs, returnedMac)
+			}
 			continue
-		} // Handle description field which can be null in API response
-		var description string
-		if desc := macMap["Description"]; desc != nil {
-			description = desc.(string)
-		} else {
-			description = ""
 		}
 
+		// The description can be null in the API response, and carries the owner and ticket of the entry
 		entry := map[string]interface{}{
-			"description": description,
 			"mac_address": macAddress,
 		}
+		setWhitelistEntryDescription(entry, macMap)
 		if expiration, exists := macMap["Expiration"].(string); exists && expiration != "" {
 			entry["expiration"] = expiration
 		} else {
 			entry["expiration"] = nil // Ensure the attribute is unset if no valid value exists
 		}
+		setWhitelistEntryAssignment(entry, macMap)
+		setWhitelistEntryMetadata(entry, macMap)
 		filteredMacAddresses = append(filteredMacAddresses, entry)
 	}
 
+	// Warn about expired entries the API still returns, before pruned MACs are added back from the configuration
+	returnedMacAddresses := make([]interface{}, 0, len(filteredMacAddresses))
+	for _, mac := range filteredMacAddresses {
+		returnedMacAddresses = append(returnedMacAddresses, mac)
+	}
+	diags := expiredEntriesWarning(accountName, returnedMacAddresses, time.Now())
+
 	// Sort the MAC addresses by their mac_address and description fields to ensure consistent ordering
 	sort.SliceStable(filteredMacAddresses, func(i, j int) bool {
 		if filteredMacAddresses[i]["mac_address"].(string) == filteredMacAddresses[j]["mac_address"].(string) {
@@ -300,43 +672,84 @@ func resourceMacAccountAddressesRead(ctx context.Context, d *schema.ResourceData
 		macAddressMap[mac["mac_address"].(string)] = mac
 	}
 
-	// Preserve the original order from configuration
-	orderedMacAddresses := make([]interface{}, 0)
+	// Flag entries whose device has not connected recently
+	staleMacs := make([]string, 0)
+	if window, err := parseDurationWithDays(d.Get("prune_unseen_after").(string)); err == nil && window > 0 {
+		staleMacs = staleMacAddresses(filteredMacAddresses, window, time.Now())
+	}
 
-	// Use the original order if available, otherwise use sorted order
-	if len(originalMacOrder) > 0 {
-		for _, macAddr := range originalMacOrder {
-			if mac, exists := macAddressMap[macAddr]; exists {
-				orderedMacAddresses = append(orderedMacAddresses, mac)
-				delete(macAddressMap, macAddr)
+	// Pruned MACs are gone from the whitelist on purpose; keep them in state so the configuration does not re-add them
+	prunedMacs := stringSet(d.Get("pruned_macs").([]interface{}))
+	if macs, ok := d.GetOk("mac_addresses"); ok && len(prunedMacs) > 0 {
+		for _, mac := range macs.([]interface{}) {
+			macMap := mac.(map[string]interface{})
+			macAddress := macMap["mac_address"].(string)
+			if _, exists := macAddressMap[macAddress]; prunedMacs[macAddress] && !exists {
+				macAddressMap[macAddress] = macMap
 			}
 		}
 	}
 
-	// If there are any MAC addresses that weren't in the original order, append them
-	if len(macAddressMap) > 0 {
-		for _, mac := range macAddressMap {
-			orderedMacAddresses = append(orderedMacAddresses, mac)
-		}
-	}
+	// Preserve the original order from configuration
+	orderedMacAddresses := orderMacAddresses(originalMacOrder, macAddressMap)
 
 	// Update the Terraform state with ordered MAC addresses (matching the configuration order)
 	d.Set("mac_addresses", orderedMacAddresses)
+	setWhitelistSummary(d, orderedMacAddresses)
+	d.Set("stale_macs", staleMacs)
+	sort.Strings(unmanagedMacs)
+	d.Set("unmanaged_macs", unmanagedMacs)
 	d.Set("account_name", accountName)
-	return nil
+
+	// The revision sent with If-Match is the one of the account, which the search endpoint does not report
+	_, accountHeaders, err := config.MakeRequestWithRetryAndHeaders(ctx, "GET", "/api/mac-based-accounts/"+accountName, nil, nil)
+	if err != nil {
+		log.Printf("[WARN] portnox_mac_account_addresses: reading the revision of account %s failed, keeping the previous etag: %s", accountName, err)
+	} else if etag := accountHeaders.Get("ETag"); etag != "" {
+		d.Set("etag", etag)
+	}
+	return diags
 }
 
 func resourceMacAccountAddressesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(*common.Config)
-	accountName := d.Get("account_name").(string)
+	if err := checkAccountRenamed(ctx, config, d); err != nil {
+		return apiErrorDiagnostics(err, "account_name")
+	}
+	accountName, err := macAccountName(ctx, config, d)
+	if err != nil {
+		return apiErrorDiagnostics(err, "account_id")
+	}
+
+	// Expand the configured MAC addresses, which may come from mac_addresses_csv
+	configuredMacs, err := expandMacAddresses(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Store the original order of mac_addresses from the config for later use
 	originalMacOrder := make([]string, 0)
-	if macs, ok := d.GetOk("mac_addresses"); ok {
-		for _, mac := range macs.([]interface{}) {
-			macMap := mac.(map[string]interface{})
-			originalMacOrder = append(originalMacOrder, macMap["mac_address"].(string))
+	for _, mac := range configuredMacs {
+		macMap := mac.(map[string]interface{})
+		originalMacOrder = append(originalMacOrder, macMap["mac_address"].(string))
+	}
+
+	// Send the whitelist revision read during refresh with every mutation so concurrent changes are rejected,
+	// following the revision returned by each response
+	etag := d.Get("etag").(string)
+	mutateWhitelist := func(method, endpoint string, payload interface{}) ([]byte, error) {
+		headers := map[string]string{}
+		if etag != "" {
+			headers["If-Match"] = etag
 		}
+		responseBody, responseHeaders, err := config.MakeRequestWithRetryAndHeaders(ctx, method, endpoint, payload, headers)
+		if err != nil {
+			return responseBody, err
+		}
+		if newETag := responseHeaders.Get("ETag"); newETag != "" {
+			etag = newETag
+		}
+		return responseBody, nil
 	}
 
 	// Prepare the current and updated lists of MAC addresses
@@ -348,18 +761,70 @@ func resourceMacAccountAddressesUpdate(ctx context.Context, d *schema.ResourceDa
 		}
 	}
 
-	// Get the updated MAC addresses (preserving the order from the config)
+	// Get the updated MAC addresses (preserving the order from the config). MAC addresses already in the whitelist
+	// keep their notation, so a configuration that only writes them differently does not remove and re-add them.
+	currentNotation := make(map[string]string, len(currentMacs))
+	for mac := range currentMacs {
+		if digits, ok := macHex(mac); ok {
+			currentNotation[digits] = mac
+		}
+	}
 	updatedMacs := make(map[string]map[string]interface{})
-	if macs, ok := d.GetOk("mac_addresses"); ok {
-		for _, mac := range macs.([]interface{}) {
-			macMap := mac.(map[string]interface{})
-			updatedMacs[macMap["mac_address"].(string)] = macMap
+	for _, mac := range configuredMacs {
+		macMap := mac.(map[string]interface{})
+		if digits, ok := macHex(macMap["mac_address"].(string)); ok && currentNotation[digits] != "" {
+			macMap["mac_address"] = currentNotation[digits]
 		}
+		updatedMacs[macMap["mac_address"].(string)] = macMap
 	}
 
-	// Identify MAC addresses to remove
-	for mac := range currentMacs {
-		if _, exists := updatedMacs[mac]; !exists {
+	// Previously pruned MACs are no longer in the whitelist. When pruning is turned off they are re-added below.
+	prunedMacs := make(map[string]bool)
+	for mac := range stringSet(d.Get("pruned_macs").([]interface{})) {
+		delete(currentMacs, mac)
+		if _, configured := updatedMacs[mac]; configured && d.Get("prune").(bool) {
+			prunedMacs[mac] = true
+		}
+	}
+
+	// MACs removed from the whitelist for good, checked when verify_writes is set
+	removedMacs := make([]string, 0)
+	// MACs removed so they can be re-added with changed attributes
+	removedForUpdate := make(map[string]bool)
+
+	// Remove the stale MACs flagged during the last refresh
+	if d.Get("prune").(bool) {
+		staleMacs := make([]map[string]interface{}, 0)
+		for mac := range stringSet(d.Get("stale_macs").([]interface{})) {
+			if _, exists := currentMacs[mac]; !exists {
+				continue
+			}
+			staleMacs = append(staleMacs, map[string]interface{}{"Mac": mac})
+			removedMacs = append(removedMacs, mac)
+			delete(currentMacs, mac)
+			if _, configured := updatedMacs[mac]; configured {
+				prunedMacs[mac] = true
+			}
+		}
+		if len(staleMacs) > 0 {
+			payload := map[string]interface{}{
+				"AccountName":  accountName,
+				"MacWhiteList": staleMacs,
+			}
+			if _, err := mutateWhitelist("DELETE", "/api/mac-based-accounts/mac-whitelist-remove", payload); err != nil {
+				return apiErrorDiagnostics(err, "prune")
+			}
+		}
+	}
+
+	adds, removes, updates := whitelistdiff.ComputeDelta(currentMacs, updatedMacs)
+	upsert := config.WhitelistUpsert()
+
+	// Without in-place updates, MAC addresses whose description, expiration or VLAN assignment changed are removed,
+	// once each, right before they are added again with the new attributes
+	if !upsert {
+		for _, entry := range updates {
+			mac := entry["mac_address"].(string)
 			payload := map[string]interface{}{
 				"AccountName": accountName,
 				"MacWhiteList": []map[string]interface{}{
@@ -367,92 +832,90 @@ func resourceMacAccountAddressesUpdate(ctx context.Context, d *schema.ResourceDa
 				},
 			}
 			endpoint := "/api/mac-based-accounts/mac-whitelist-remove"
-			if _, err := config.MakeRequestWithRetry("DELETE", endpoint, payload); err != nil {
-				return diag.FromErr(err)
+			if _, err := mutateWhitelist("DELETE", endpoint, payload); err != nil {
+				return apiErrorDiagnostics(err, "mac_addresses")
 			}
+			removedForUpdate[mac] = true
 		}
 	}
-	// Identify MAC addresses with updated descriptions
-	for mac, currentMac := range currentMacs {
-		if updatedMac, exists := updatedMacs[mac]; exists {
-			if currentMac["description"] != updatedMac["description"] {
-				payload := map[string]interface{}{
-					"AccountName": accountName,
-					"MacWhiteList": []map[string]interface{}{
-						{
-							"Mac":         mac,
-							"Description": updatedMac["description"],
-						},
-					},
-				}
-				endpoint := "/api/mac-based-accounts/mac-whitelist-remove"
-				if _, err := config.MakeRequestWithRetry("DELETE", endpoint, payload); err != nil {
-					return diag.FromErr(err)
-				}
-			}
+
+	// Only send the new and changed MAC addresses, as re-posting unchanged ones is slow for large accounts
+	macAddresses := make([]map[string]interface{}, 0, len(adds)+len(updates))
+	for _, macMap := range append(adds, updates...) {
+		if prunedMacs[macMap["mac_address"].(string)] {
+			continue
 		}
+		macAddresses = append(macAddresses, whitelistEntry(macMap))
 	}
 
-	// Identify MAC addresses with updated expirations
-	for mac, currentMac := range currentMacs {
-		if updatedMac, exists := updatedMacs[mac]; exists {
-			currentExpiration, currentHasExpiration := currentMac["expiration"].(string)
-			updatedExpiration, updatedHasExpiration := updatedMac["expiration"].(string)
-
-			// Check if expiration has changed
-			if (currentHasExpiration != updatedHasExpiration) || (currentHasExpiration && updatedHasExpiration && currentExpiration != updatedExpiration) {
-				payload := map[string]interface{}{
-					"AccountName": accountName,
-					"MacWhiteList": []map[string]interface{}{
-						{
-							"Mac": mac,
-						},
-					},
-				}
-
-				// Add expiration only if it exists
-				if updatedHasExpiration && updatedExpiration != "" {
-					payload["MacWhiteList"].([]map[string]interface{})[0]["Expiration"] = updatedExpiration
-				}
+	var responseBody []byte
+	if len(macAddresses) > 0 {
+		payload := map[string]interface{}{
+			"AccountName":  accountName,
+			"MacWhiteList": macAddresses,
+		}
+		endpoint := "/api/mac-based-accounts/mac-whitelist-add"
+		responseBody, err = mutateWhitelist("POST", endpoint, payload)
+	}
 
-				endpoint := "/api/mac-based-accounts/mac-whitelist-remove"
-				if _, err := config.MakeRequestWithRetry("DELETE", endpoint, payload); err != nil {
-					return diag.FromErr(err)
-				}
+	// When the API rejects only some entries, report them and leave the ones no longer in the whitelist out of state
+	failures := common.WhitelistAddFailures(macAddresses, responseBody, err)
+	if err != nil && failures == nil {
+		// Put the changed entries back with their previous attributes so their devices stay authorized
+		if len(removedForUpdate) > 0 {
+			restore := make([]map[string]interface{}, 0, len(removedForUpdate))
+			for _, entry := range updates {
+				mac := entry["mac_address"].(string)
+				restore = append(restore, whitelistEntry(currentMacs[mac]))
+			}
+			payload := map[string]interface{}{
+				"AccountName":  accountName,
+				"MacWhiteList": restore,
+			}
+			if _, restoreErr := mutateWhitelist("POST", "/api/mac-based-accounts/mac-whitelist-add", payload); restoreErr != nil {
+				log.Printf("[WARN] portnox_mac_account_addresses: restoring the changed MAC addresses of account %s failed: %s", accountName, restoreErr)
 			}
 		}
+		return apiErrorDiagnostics(err, "mac_addresses")
 	}
 
-	// Prepare the payload with the updated list of MAC addresses to add or update
-	macAddresses := make([]map[string]interface{}, 0)
-	for _, macMap := range updatedMacs {
-		entry := map[string]interface{}{
-			"Mac":         macMap["mac_address"].(string),
-			"Description": macMap["description"].(string),
+	// Remove the MAC addresses that are no longer configured only after the additions, so a failure in between
+	// leaves extra devices authorized rather than configured ones without access
+	for _, entry := range removes {
+		mac := entry["mac_address"].(string)
+		removedMacs = append(removedMacs, mac)
+		payload := map[string]interface{}{
+			"AccountName": accountName,
+			"MacWhiteList": []map[string]interface{}{
+				{"Mac": mac},
+			},
 		}
-		if expiration, exists := macMap["expiration"].(string); exists && expiration != "" {
-			entry["Expiration"] = expiration
+		endpoint := "/api/mac-based-accounts/mac-whitelist-remove"
+		if _, err := mutateWhitelist("DELETE", endpoint, payload); err != nil {
+			return apiErrorDiagnostics(err, "mac_addresses")
 		}
-		macAddresses = append(macAddresses, entry)
-	}
-
-	payload := map[string]interface{}{
-		"AccountName":  accountName,
-		"MacWhiteList": macAddresses,
-	}
-	endpoint := "/api/mac-based-accounts/mac-whitelist-add"
-	if _, err := config.MakeRequestWithRetry("POST", endpoint, payload); err != nil {
-		return diag.FromErr(err)
 	}
 
 	// Create a map of mac_address to its data for easy lookup
 	macAddressMap := make(map[string]map[string]interface{})
-	if macs, ok := d.GetOk("mac_addresses"); ok {
-		for _, mac := range macs.([]interface{}) {
-			macMap := mac.(map[string]interface{})
-			macAddressMap[macMap["mac_address"].(string)] = macMap
+	for _, mac := range configuredMacs {
+		macMap := mac.(map[string]interface{})
+		macAddressMap[macMap["mac_address"].(string)] = macMap
+	}
+
+	// A rejected new or re-added entry is not in the whitelist, while a rejected in-place update keeps the previous
+	// attributes of the entry
+	rejected := make(map[string]bool, len(failures))
+	for mac := range failures {
+		if currentMac, existed := currentMacs[mac]; existed && !removedForUpdate[mac] {
+			macAddressMap[mac] = currentMac
+		} else {
+			rejected[mac] = true
 		}
 	}
+	if err := verifyWhitelistWrites(ctx, config, accountName, acceptedWhitelistEntries(macAddresses, failures), removedMacs); err != nil {
+		return apiErrorDiagnostics(err, "mac_addresses")
+	}
 
 	// Preserve the original order from configuration
 	orderedMacAddresses := make([]interface{}, 0)
@@ -464,24 +927,52 @@ func resourceMacAccountAddressesUpdate(ctx context.Context, d *schema.ResourceDa
 		}
 	}
 
+	prunedList := make([]string, 0, len(prunedMacs))
+	for mac := range prunedMacs {
+		prunedList = append(prunedList, mac)
+	}
+	sort.Strings(prunedList)
+
 	// Update the Terraform state preserving the configuration's order
+	orderedMacAddresses = withoutMacs(orderedMacAddresses, rejected)
 	d.Set("mac_addresses", orderedMacAddresses)
+	setWhitelistSummary(d, orderedMacAddresses)
+	d.Set("pruned_macs", prunedList)
+	if d.Get("prune").(bool) {
+		d.Set("stale_macs", []string{})
+	}
 	d.Set("account_name", accountName)
-	return nil
+	d.Set("etag", etag)
+	// The resource is identified by the account name, so the ID follows a rename
+	d.SetId(accountName)
+	return whitelistFailureDiagnostics(failures, "mac_addresses")
 }
 
 func resourceMacAccountAddressesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	config := m.(*common.Config)
-	accountName := d.Get("account_name").(string)
+	accountName, err := macAccountName(ctx, config, d)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return apiErrorDiagnostics(err, "")
+	}
 
 	payload := map[string]interface{}{
 		"AccountName":  accountName,
 		"MacWhiteList": []map[string]interface{}{},
 	}
 
+	// Pruned MACs were already removed from the whitelist
+	prunedMacs := stringSet(d.Get("pruned_macs").([]interface{}))
+
 	if macAddresses, ok := d.GetOk("mac_addresses"); ok {
 		for _, mac := range macAddresses.([]interface{}) {
 			macMap := mac.(map[string]interface{})
+			if prunedMacs[macMap["mac_address"].(string)] {
+				continue
+			}
 			entry := map[string]interface{}{
 				"Mac": macMap["mac_address"].(string),
 			}
@@ -490,8 +981,16 @@ func resourceMacAccountAddressesDelete(ctx context.Context, d *schema.ResourceDa
 	}
 
 	endpoint := "/api/mac-based-accounts/mac-whitelist-remove"
-	if _, err := config.MakeRequestWithRetry("DELETE", endpoint, payload); err != nil {
-		return diag.FromErr(err)
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", endpoint, payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	removedMacs := make([]string, 0)
+	for _, entry := range payload["MacWhiteList"].([]map[string]interface{}) {
+		removedMacs = append(removedMacs, entry["Mac"].(string))
+	}
+	if err := verifyWhitelistWrites(ctx, config, accountName, nil, removedMacs); err != nil {
+		return apiErrorDiagnostics(err, "")
 	}
 	d.SetId("")
 	return nil
@@ -502,78 +1001,80 @@ func resourceMacAccountAddressesImport(ctx context.Context, d *schema.ResourceDa
 	config := m.(*common.Config)
 
 	// Parse the ID - it may contain specific MAC addresses to import
-	// Format: accountName or accountName,mac1;mac2;mac3
-	importParts := strings.Split(d.Id(), ",")
-	accountName := importParts[0]
+	// Format: accountName, accountName,*, accountName,prefix:AA:BB:CC or accountName,mac1;mac2;mac3
+	const importFormat = "<account>, <account>,*, <account>,prefix:<oui> or <account>,<mac1>;<mac2>"
+	importParts := strings.SplitN(d.Id(), ",", 2)
+	accountName := strings.TrimSpace(importParts[0])
+	if accountName == "" {
+		return nil, fmt.Errorf("invalid import ID %q, expected %s", d.Id(), importFormat)
+	}
 
-	// Create a filter of specific MAC addresses to import if provided
+	// Create a filter of specific MAC addresses or an OUI prefix to import if provided. MAC addresses are compared
+	// by their digits, so any separator style or letter case matches.
 	macFilter := make(map[string]bool)
 	hasFilter := false
-	if len(importParts) > 1 && importParts[1] != "" {
-		macList := strings.Split(importParts[1], ";")
-		for _, mac := range macList {
-			macFilter[strings.TrimSpace(mac)] = true
+	prefixFilter := ""
+	if len(importParts) > 1 {
+		filter := strings.TrimSpace(importParts[1])
+		switch {
+		case filter == "" || filter == "*":
+			// Import the whole whitelist
+		case strings.HasPrefix(filter, "prefix:"):
+			prefixFilter = strings.TrimSpace(strings.TrimPrefix(filter, "prefix:"))
+			if _, ok := macHex(prefixFilter); !ok {
+				return nil, fmt.Errorf("invalid OUI prefix %q in import ID, expected a form like %s,prefix:AA:BB:CC", prefixFilter, accountName)
+			}
+			hasFilter = true
+		default:
+			for _, mac := range strings.Split(filter, ";") {
+				mac = strings.TrimSpace(mac)
+				digits, ok := macHex(mac)
+				if !ok || len(digits) != 12 {
+					return nil, fmt.Errorf("invalid MAC address %q in import ID %q, expected %s", mac, d.Id(), importFormat)
+				}
+				macFilter[digits] = true
+			}
+			hasFilter = true
 		}
-		hasFilter = true
 	}
 
-	// Set the account name in the resource data
+	// Set the account name and the arguments the API does not return in the resource data
+	d.SetId(accountName)
 	d.Set("account_name", accountName)
+	setSchemaDefaults(d, ResourceMacAccountAddresses().Schema)
 
 	// Make a request to get all MAC addresses for this account
-	responseBody, err := config.MakeRequestWithRetry("GET", "/api/mac-based-accounts/"+accountName, nil)
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+accountName, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving MAC account %s: %s", accountName, err)
 	}
 
 	// Parse the response
-	var accountData map[string]interface{}
-	if err := json.Unmarshal(responseBody, &accountData); err != nil {
+	var account common.MacAccount
+	if err := json.Unmarshal(responseBody, &account); err != nil {
 		return nil, fmt.Errorf("error parsing API response: %s", err)
 	}
 
 	// Extract the MAC whitelist from the response
-	agentlessOptions, ok := accountData["AgentlessOptions"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("AgentlessOptions not found in response or has unexpected type")
-	}
-	// Access MacWhiteList directly as an array from agentlessOptions
-	var macWhiteList []interface{}
-	// Based on the API schema, MacWhiteList is now a direct array
-	if macArray, ok := agentlessOptions["MacWhiteList"].([]interface{}); ok {
-		macWhiteList = macArray
-	} else if macMap, ok := agentlessOptions["MacWhiteList"].(map[string]interface{}); ok {
-		// If MacWhiteList is a map with _items (older API versions)
-		if items, ok := macMap["_items"].([]interface{}); ok {
-			macWhiteList = items
-		} else {
-			// Empty list as fallback
-			macWhiteList = []interface{}{}
-		}
-	} else {
-		// No MAC addresses found or unexpected format, but still valid (empty list)
-		macWhiteList = []interface{}{}
+	if account.AgentlessOptions == nil {
+		return nil, fmt.Errorf("AgentlessOptions not found in response")
 	}
+	macWhiteList := accountMacWhiteList(config, account)
 
 	// Transform the MAC addresses into the format expected by Terraform
 	macAddresses := make([]map[string]interface{}, 0, len(macWhiteList))
-	for _, item := range macWhiteList {
-		if item == nil {
-			continue
-		}
-
-		macMap, ok := item.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
+	for _, macMap := range macWhiteList {
 		macAddress, ok := macMap["Mac"].(string)
 		if !ok || macAddress == "" {
 			continue
 		}
 
-		// If we have a MAC filter, only include MACs that are in the filter
-		if hasFilter && !macFilter[macAddress] {
+		// If we have a MAC or prefix filter, only include MACs that match it
+		if prefixFilter != "" {
+			if !macMatchesPrefix(macAddress, prefixFilter) {
+				continue
+			}
+		} else if digits, _ := macHex(macAddress); hasFilter && !macFilter[digits] {
 			continue
 		}
 
@@ -582,23 +1083,24 @@ func resourceMacAccountAddressesImport(ctx context.Context, d *schema.ResourceDa
 			"mac_address": macAddress,
 		}
 
-		// Handle description (may be null)
-		if desc, ok := macMap["Description"].(string); ok {
-			entry["description"] = desc
-		} else {
-			entry["description"] = ""
-		}
+		// Handle description (may be null), which carries the owner and ticket of the entry
+		setWhitelistEntryDescription(entry, macMap)
 
 		// Handle expiration (may be null)
 		if exp, ok := macMap["Expiration"].(string); ok && exp != "" {
 			entry["expiration"] = exp
 		}
+		setWhitelistEntryAssignment(entry, macMap)
+		setWhitelistEntryMetadata(entry, macMap)
 
 		macAddresses = append(macAddresses, entry)
 	}
 
 	// If we have a filter but no MAC addresses matched, return an error
 	if hasFilter && len(macAddresses) == 0 {
+		if prefixFilter != "" {
+			return nil, fmt.Errorf("no MAC addresses matching prefix %s were found in account %s", prefixFilter, accountName)
+		}
 		return nil, fmt.Errorf("none of the specified MAC addresses were found in account %s", accountName)
 	}
 
@@ -0,0 +1,118 @@
+package providers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func macAddressEntry(mac, description string) map[string]interface{} {
+	return map[string]interface{}{"mac_address": mac, "description": description}
+}
+
+// entryKeys returns the mac_address and description of each entry, in order
+func entryKeys(entries []interface{}) []string {
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		macMap := entry.(map[string]interface{})
+		keys = append(keys, macMap["mac_address"].(string)+"/"+macMap["description"].(string))
+	}
+	return keys
+}
+
+func TestSortMacAddresses(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries []interface{}
+		want    []string
+	}{
+		{
+			name:    "empty",
+			entries: []interface{}{},
+			want:    []string{},
+		},
+		{
+			name: "by mac address",
+			entries: []interface{}{
+				macAddressEntry("AA:BB:CC:DD:EE:03", "c"),
+				macAddressEntry("AA:BB:CC:DD:EE:01", "a"),
+				macAddressEntry("AA:BB:CC:DD:EE:02", "b"),
+			},
+			want: []string{"AA:BB:CC:DD:EE:01/a", "AA:BB:CC:DD:EE:02/b", "AA:BB:CC:DD:EE:03/c"},
+		},
+		{
+			name: "by description for the same mac address",
+			entries: []interface{}{
+				macAddressEntry("AA:BB:CC:DD:EE:01", "printer"),
+				macAddressEntry("AA:BB:CC:DD:EE:01", "camera"),
+			},
+			want: []string{"AA:BB:CC:DD:EE:01/camera", "AA:BB:CC:DD:EE:01/printer"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := append([]interface{}{}, tc.entries...)
+			if got := entryKeys(sortMacAddresses(tc.entries)); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("sortMacAddresses() = %v, want %v", got, tc.want)
+			}
+			if !reflect.DeepEqual(tc.entries, input) {
+				t.Errorf("sortMacAddresses() modified its input")
+			}
+		})
+	}
+}
+
+func TestOrderMacAddresses(t *testing.T) {
+	entries := func(macs ...string) map[string]map[string]interface{} {
+		result := make(map[string]map[string]interface{}, len(macs))
+		for _, mac := range macs {
+			result[mac] = macAddressEntry(mac, "")
+		}
+		return result
+	}
+
+	cases := []struct {
+		name    string
+		order   []string
+		entries map[string]map[string]interface{}
+		want    []string
+	}{
+		{
+			name:    "configuration order is kept",
+			order:   []string{"AA:BB:CC:DD:EE:03", "AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"},
+			entries: entries("AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02", "AA:BB:CC:DD:EE:03"),
+			want:    []string{"AA:BB:CC:DD:EE:03/", "AA:BB:CC:DD:EE:01/", "AA:BB:CC:DD:EE:02/"},
+		},
+		{
+			name:    "entries missing from the API are dropped",
+			order:   []string{"AA:BB:CC:DD:EE:02", "AA:BB:CC:DD:EE:01"},
+			entries: entries("AA:BB:CC:DD:EE:01"),
+			want:    []string{"AA:BB:CC:DD:EE:01/"},
+		},
+		{
+			name:    "entries not in the configuration are appended in sorted order",
+			order:   []string{"AA:BB:CC:DD:EE:02"},
+			entries: entries("AA:BB:CC:DD:EE:04", "AA:BB:CC:DD:EE:02", "AA:BB:CC:DD:EE:03", "AA:BB:CC:DD:EE:01"),
+			want:    []string{"AA:BB:CC:DD:EE:02/", "AA:BB:CC:DD:EE:01/", "AA:BB:CC:DD:EE:03/", "AA:BB:CC:DD:EE:04/"},
+		},
+		{
+			name:    "duplicates in the configuration are listed once",
+			order:   []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:01"},
+			entries: entries("AA:BB:CC:DD:EE:01"),
+			want:    []string{"AA:BB:CC:DD:EE:01/"},
+		},
+		{
+			name:    "no configuration order",
+			entries: entries("AA:BB:CC:DD:EE:02", "AA:BB:CC:DD:EE:01"),
+			want:    []string{"AA:BB:CC:DD:EE:01/", "AA:BB:CC:DD:EE:02/"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := entryKeys(orderMacAddresses(tc.order, tc.entries)); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("orderMacAddresses() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,414 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+	"github.com/portnox-community/terraform-provider-portnox/internal/whitelistdiff"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceMacWhitelist manages the MAC whitelists of several MAC-based accounts from one resource, sending at
+// most one add and one remove request per account for each change
+func ResourceMacWhitelist() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMacWhitelistCreate,
+		ReadContext:   resourceMacWhitelistRead,
+		UpdateContext: resourceMacWhitelistUpdate,
+		DeleteContext: resourceMacWhitelistDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMacWhitelistImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"account": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the MAC-based account.",
+						},
+						"mac_addresses": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"mac_address": {
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateFunc:     validation.StringMatch(macAddressPattern, macAddressMessage),
+										StateFunc:        normalizeMacAddressState,
+										DiffSuppressFunc: suppressEquivalentMacAddress,
+										Description:      "The MAC address to be added to the whitelist.",
+									},
+									"description": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ValidateFunc: validation.All(
+											validation.StringLenBetween(0, maxWhitelistDescriptionLength),
+											validation.StringMatch(macDescriptionPattern, macDescriptionMessage),
+										),
+										Description: "A description of the MAC address.",
+									},
+									"expiration": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The expiration date/time of the MAC address.",
+									},
+								},
+							},
+							Description: "The MAC addresses whitelisted in the account.",
+						},
+					},
+				},
+				Description: "The accounts whose whitelists are managed, each with its MAC addresses.",
+			},
+		},
+	}
+}
+
+// expandMacWhitelistAccounts returns the configured entries of an account set keyed by account name and MAC address
+func expandMacWhitelistAccounts(accounts *schema.Set) map[string]map[string]map[string]interface{} {
+	expanded := make(map[string]map[string]map[string]interface{})
+	for _, account := range accounts.List() {
+		accountMap := account.(map[string]interface{})
+		entries := make(map[string]map[string]interface{})
+		for _, mac := range accountMap["mac_addresses"].(*schema.Set).List() {
+			macMap := mac.(map[string]interface{})
+			entries[macMap["mac_address"].(string)] = macMap
+		}
+		expanded[accountMap["account_name"].(string)] = entries
+	}
+	return expanded
+}
+
+// validateMacWhitelistAccounts rejects account names that appear in more than one account block
+func validateMacWhitelistAccounts(accounts *schema.Set) error {
+	seen := make(map[string]bool)
+	for _, account := range accounts.List() {
+		name := account.(map[string]interface{})["account_name"].(string)
+		if seen[name] {
+			return fmt.Errorf("account %s is declared more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// sendMacWhitelistChanges adds and removes the given entries of an account and verifies the result when
+// verify_writes is set. Entries that are also added back are removed first, and restored with the attributes they
+// are removed with when the addition fails; the other removals are sent after the additions, so a failure in between
+// never leaves a configured device without access. It returns the entries the API rejected, mapped to the reason.
+func sendMacWhitelistChanges(ctx context.Context, config *common.Config, accountName string, remove, add []map[string]interface{}) (map[string]string, error) {
+	adding := make(map[string]bool, len(add))
+	for _, entry := range add {
+		adding[entry["Mac"].(string)] = true
+	}
+	replaced := make([]map[string]interface{}, 0)
+	removing := make([]map[string]interface{}, 0, len(remove))
+	for _, entry := range remove {
+		if adding[entry["Mac"].(string)] {
+			replaced = append(replaced, entry)
+		} else {
+			removing = append(removing, entry)
+		}
+	}
+
+	removeEntries := func(entries []map[string]interface{}) error {
+		if len(entries) == 0 {
+			return nil
+		}
+		macs := make([]map[string]interface{}, 0, len(entries))
+		for _, entry := range entries {
+			macs = append(macs, map[string]interface{}{"Mac": entry["Mac"]})
+		}
+		payload := map[string]interface{}{
+			"AccountName":  accountName,
+			"MacWhiteList": macs,
+		}
+		_, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/mac-based-accounts/mac-whitelist-remove", payload)
+		return err
+	}
+
+	if err := removeEntries(replaced); err != nil {
+		return nil, err
+	}
+	var failures map[string]string
+	if len(add) > 0 {
+		payload := map[string]interface{}{
+			"AccountName":  accountName,
+			"MacWhiteList": add,
+		}
+		responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts/mac-whitelist-add", payload)
+		failures = common.WhitelistAddFailures(add, responseBody, err)
+		if err != nil && failures == nil {
+			// Put the replaced entries back with their previous attributes so their devices stay authorized
+			if len(replaced) > 0 {
+				payload := map[string]interface{}{
+					"AccountName":  accountName,
+					"MacWhiteList": replaced,
+				}
+				if _, restoreErr := config.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts/mac-whitelist-add", payload); restoreErr != nil {
+					log.Printf("[WARN] Restoring the changed MAC addresses of account %s failed: %s", accountName, restoreErr)
+				}
+			}
+			return nil, err
+		}
+		add = acceptedWhitelistEntries(add, failures)
+	}
+	if err := removeEntries(removing); err != nil {
+		return nil, err
+	}
+
+	// Changed entries are both removed and added, so only verify the removal of MACs that are not added back
+	removed := make([]string, 0, len(removing))
+	for _, entry := range removing {
+		removed = append(removed, entry["Mac"].(string))
+	}
+	return failures, verifyWhitelistWrites(ctx, config, accountName, add, removed)
+}
+
+func resourceMacWhitelistCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	accounts := d.Get("account").(*schema.Set)
+	if err := validateMacWhitelistAccounts(accounts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Rejected entries are reported, and left out of state by the read
+	var diags diag.Diagnostics
+	accountNames := make([]string, 0, accounts.Len())
+	for accountName, entries := range expandMacWhitelistAccounts(accounts) {
+		add := make([]map[string]interface{}, 0, len(entries))
+		for _, entry := range entries {
+			add = append(add, whitelistEntry(entry))
+		}
+		failures, err := sendMacWhitelistChanges(ctx, config, accountName, nil, add)
+		if err != nil {
+			return apiErrorDiagnostics(err, "account")
+		}
+		diags = append(diags, whitelistFailureDiagnostics(failures, "account")...)
+		accountNames = append(accountNames, accountName)
+	}
+
+	sort.Strings(accountNames)
+	d.SetId(strings.Join(accountNames, ","))
+
+	return append(diags, resourceMacWhitelistRead(ctx, d, m)...)
+}
+
+func resourceMacWhitelistRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	var diags diag.Diagnostics
+	accounts := make([]interface{}, 0)
+
+	for accountName, stateEntries := range expandMacWhitelistAccounts(d.Get("account").(*schema.Set)) {
+		responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+accountName, nil)
+		if err != nil {
+			if config.IsNotFoundError(err) {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Account not found",
+					Detail:   fmt.Sprintf("Account %s no longer exists and its whitelist will be recreated on the next apply.", accountName),
+				})
+				continue
+			}
+			return apiErrorDiagnostics(err, "")
+		}
+
+		var account common.MacAccount
+		if err := json.Unmarshal(responseBody, &account); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Only track the MAC addresses managed by this resource, matched on their hex digits as the API may return
+		// another notation than the configuration
+		managedEntries := make(map[string]map[string]interface{}, len(stateEntries))
+		for macAddress, stateEntry := range stateEntries {
+			if digits, ok := macHex(macAddress); ok {
+				managedEntries[digits] = stateEntry
+			}
+		}
+		macAddresses := make([]interface{}, 0)
+		for _, macMap := range accountMacWhiteList(config, account) {
+			returnedMac, _ := macMap["Mac"].(string)
+			digits, _ := macHex(returnedMac)
+			stateEntry, managed := managedEntries[digits]
+			if !managed {
+				continue
+			}
+			macAddress := stateEntry["mac_address"].(string)
+
+			description, _ := macMap["Description"].(string)
+			expiration, _ := macMap["Expiration"].(string)
+			// Keep the configured expiration format when the API returns the same time
+			if stateExpiration, _ := stateEntry["expiration"].(string); expiration == "" || strings.HasPrefix(expiration, strings.TrimSuffix(stateExpiration, "Z")) {
+				expiration = stateExpiration
+			}
+
+			macAddresses = append(macAddresses, map[string]interface{}{
+				"mac_address": macAddress,
+				"description": description,
+				"expiration":  expiration,
+			})
+		}
+
+		diags = append(diags, expiredEntriesWarning(accountName, macAddresses, time.Now())...)
+
+		accounts = append(accounts, map[string]interface{}{
+			"account_name":  accountName,
+			"mac_addresses": macAddresses,
+		})
+	}
+
+	if len(accounts) == 0 {
+		return removeFromState(d, "portnox_mac_whitelist", "none of the accounts exist")
+	}
+
+	if err := d.Set("account", accounts); err != nil {
+		return diag.Errorf("error setting account: %s", err)
+	}
+
+	return diags
+}
+
+// resourceMacWhitelistImport imports the whole whitelists of a comma-separated list of accounts, which are then
+// managed by the resource
+func resourceMacWhitelistImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	config := m.(*common.Config)
+
+	const format = "a comma-separated list of MAC-based account names"
+	accountNames := strings.Split(d.Id(), ",")
+	seen := make(map[string]bool, len(accountNames))
+	for _, accountName := range accountNames {
+		if strings.TrimSpace(accountName) == "" || strings.TrimSpace(accountName) != accountName || strings.ContainsAny(accountName, importIDSeparators) {
+			return nil, fmt.Errorf("invalid import ID %q, expected %s", d.Id(), format)
+		}
+		if seen[accountName] {
+			return nil, fmt.Errorf("invalid import ID %q: account %s is listed more than once", d.Id(), accountName)
+		}
+		seen[accountName] = true
+	}
+
+	accounts := make([]interface{}, 0, len(accountNames))
+	for _, accountName := range accountNames {
+		responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+accountName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving MAC account %s: %s", accountName, err)
+		}
+		var account common.MacAccount
+		if err := json.Unmarshal(responseBody, &account); err != nil {
+			return nil, fmt.Errorf("error parsing API response: %s", err)
+		}
+
+		macAddresses := make([]interface{}, 0)
+		for _, macMap := range accountMacWhiteList(config, account) {
+			macAddress, _ := macMap["Mac"].(string)
+			description, _ := macMap["Description"].(string)
+			expiration, _ := macMap["Expiration"].(string)
+			macAddresses = append(macAddresses, map[string]interface{}{
+				"mac_address": normalizeMacAddress(macAddress),
+				"description": description,
+				"expiration":  expiration,
+			})
+		}
+		accounts = append(accounts, map[string]interface{}{
+			"account_name":  accountName,
+			"mac_addresses": macAddresses,
+		})
+	}
+
+	if err := d.Set("account", accounts); err != nil {
+		return nil, fmt.Errorf("error setting account: %s", err)
+	}
+	sort.Strings(accountNames)
+	d.SetId(strings.Join(accountNames, ","))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceMacWhitelistUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	oldAccounts, newAccounts := d.GetChange("account")
+	if err := validateMacWhitelistAccounts(newAccounts.(*schema.Set)); err != nil {
+		return diag.FromErr(err)
+	}
+	current := expandMacWhitelistAccounts(oldAccounts.(*schema.Set))
+	desired := expandMacWhitelistAccounts(newAccounts.(*schema.Set))
+
+	// Clear the whitelists of accounts that are no longer managed
+	for accountName, entries := range current {
+		if _, exists := desired[accountName]; exists {
+			continue
+		}
+		remove := make([]map[string]interface{}, 0, len(entries))
+		for mac := range entries {
+			remove = append(remove, map[string]interface{}{"Mac": mac})
+		}
+		if _, err := sendMacWhitelistChanges(ctx, config, accountName, remove, nil); err != nil {
+			return apiErrorDiagnostics(err, "account")
+		}
+	}
+
+	// Send only the entries that changed. A changed entry is removed and added again, unless the tenant updates
+	// entries in place.
+	var diags diag.Diagnostics
+	for accountName, entries := range desired {
+		adds, removes, updates := whitelistdiff.ComputeDelta(current[accountName], entries)
+
+		// With in-place updates, changed entries are only added again
+		if !config.WhitelistUpsert() {
+			removes = append(removes, updates...)
+		}
+		remove := make([]map[string]interface{}, 0, len(removes))
+		for _, entry := range removes {
+			remove = append(remove, whitelistEntry(entry))
+		}
+		add := make([]map[string]interface{}, 0, len(adds)+len(updates))
+		for _, entry := range append(adds, updates...) {
+			add = append(add, whitelistEntry(entry))
+		}
+
+		failures, err := sendMacWhitelistChanges(ctx, config, accountName, remove, add)
+		if err != nil {
+			return apiErrorDiagnostics(err, "account")
+		}
+		diags = append(diags, whitelistFailureDiagnostics(failures, "account")...)
+	}
+
+	return append(diags, resourceMacWhitelistRead(ctx, d, m)...)
+}
+
+func resourceMacWhitelistDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	for accountName, entries := range expandMacWhitelistAccounts(d.Get("account").(*schema.Set)) {
+		remove := make([]map[string]interface{}, 0, len(entries))
+		for mac := range entries {
+			remove = append(remove, map[string]interface{}{"Mac": mac})
+		}
+		if _, err := sendMacWhitelistChanges(ctx, config, accountName, remove, nil); err != nil {
+			if !config.IsNotFoundError(err) {
+				return apiErrorDiagnostics(err, "")
+			}
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceNasDevice registers a NAS device. A device can use the shared secret of the organization or a RADIUS
+// shared secret of its own, which Portnox generates and which is rotated whenever rotate_secret_on changes.
+func ResourceNasDevice() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNasDeviceCreate,
+		ReadContext:   resourceNasDeviceRead,
+		UpdateContext: resourceNasDeviceUpdate,
+		DeleteContext: resourceNasDeviceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the NAS device", ResourceNasDevice),
+		},
+		CustomizeDiff: resourceNasDeviceCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the NAS device.",
+			},
+			"ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsIPAddress,
+				Description:  "The IP address the NAS device sends RADIUS requests from.",
+			},
+			"site": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The site the NAS device is registered in.",
+			},
+			"vendor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The vendor of the NAS device, e.g. Cisco or Aruba, used to select vendor-specific RADIUS attributes.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the NAS device.",
+			},
+			"shared_secret_source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "organization",
+				ValidateFunc: validation.StringInSlice([]string{"organization", "device"}, false),
+				Description:  "Where the RADIUS shared secret of the device comes from: organization for the shared secret of the organization, or device for a secret generated for this device.",
+			},
+			"rotate_secret_on": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value that generates a new shared secret for the device whenever it changes, for scheduled rotation. The previous secret stops working. Requires shared_secret_source device.",
+			},
+			"shared_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The RADIUS shared secret generated for the device. Only returned by the API when generated.",
+			},
+			"shared_secret_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the shared secret of the device.",
+			},
+			"secret_rotated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the shared secret of the device was last generated by Terraform.",
+			},
+		},
+	}
+}
+
+func resourceNasDeviceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("shared_secret_source").(string) != "device" && d.Get("rotate_secret_on").(string) != "" {
+		return fmt.Errorf("rotate_secret_on requires shared_secret_source to be %q", "device")
+	}
+	// The secret changes in the same apply, so downstream switch configuration sees the new value as unknown
+	if d.Id() != "" && (d.HasChange("rotate_secret_on") || d.HasChange("shared_secret_source")) {
+		for _, attribute := range []string{"shared_secret", "shared_secret_id", "secret_rotated_at"} {
+			if err := d.SetNewComputed(attribute); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nasDevicePayload builds the API representation of the NAS device from the resource data
+func nasDevicePayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"Name":               d.Get("name").(string),
+		"IpAddress":          d.Get("ip_address").(string),
+		"Site":               d.Get("site").(string),
+		"Vendor":             d.Get("vendor").(string),
+		"Description":        d.Get("description").(string),
+		"SharedSecretSource": d.Get("shared_secret_source").(string),
+	}
+}
+
+// generateNasDeviceSecret generates a new shared secret for the device, invalidating the previous one
+func generateNasDeviceSecret(ctx context.Context, config *common.Config, d *schema.ResourceData) diag.Diagnostics {
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/nas-devices/"+d.Id()+"/shared-secret", nil)
+	if err != nil {
+		return apiErrorDiagnostics(err, "rotate_secret_on")
+	}
+
+	var secret struct {
+		SharedSecret   string `json:"SharedSecret"`
+		SharedSecretId string `json:"SharedSecretId"`
+	}
+	if err := json.Unmarshal(responseBody, &secret); err != nil {
+		return diag.FromErr(err)
+	}
+	if secret.SharedSecret == "" {
+		return diag.Errorf("the API did not return a shared secret for NAS device %s", d.Get("name").(string))
+	}
+
+	d.Set("shared_secret", secret.SharedSecret)
+	d.Set("shared_secret_id", secret.SharedSecretId)
+	d.Set("secret_rotated_at", time.Now().UTC().Format(time.RFC3339))
+
+	return nil
+}
+
+func resourceNasDeviceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/nas-devices", nasDevicePayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var device struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &device); err != nil {
+		return diag.FromErr(err)
+	}
+	if device.Id == "" {
+		return diag.Errorf("the API did not return an ID for NAS device %s", d.Get("name").(string))
+	}
+
+	d.SetId(device.Id)
+
+	if d.Get("shared_secret_source").(string) == "device" {
+		if diags := generateNasDeviceSecret(ctx, config, d); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceNasDeviceRead(ctx, d, m)
+}
+
+func resourceNasDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/nas-devices/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_nas_device", "NAS device not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var device nasDeviceResponse
+	if err := json.Unmarshal(responseBody, &device); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", device.Name)
+	d.Set("ip_address", device.IpAddress)
+	d.Set("site", device.Site)
+	d.Set("vendor", device.Vendor)
+	d.Set("description", device.Description)
+	if device.SharedSecretSource != "" {
+		d.Set("shared_secret_source", device.SharedSecretSource)
+	}
+	d.Set("shared_secret_id", device.SharedSecretId)
+
+	return nil
+}
+
+func resourceNasDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if d.HasChanges("name", "ip_address", "site", "vendor", "description", "shared_secret_source") {
+		if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/nas-devices/"+d.Id(), nasDevicePayload(d)); err != nil {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	// Switching to a secret of its own, or bumping rotate_secret_on, generates a new secret
+	if d.Get("shared_secret_source").(string) == "device" {
+		if d.HasChanges("shared_secret_source", "rotate_secret_on") {
+			if diags := generateNasDeviceSecret(ctx, config, d); diags.HasError() {
+				return diags
+			}
+		}
+	} else {
+		d.Set("shared_secret", "")
+		d.Set("secret_rotated_at", "")
+	}
+
+	return resourceNasDeviceRead(ctx, d, m)
+}
+
+func resourceNasDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/nas-devices/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
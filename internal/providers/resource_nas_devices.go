@@ -0,0 +1,517 @@
+package providers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// nasDeviceResponse is the API representation of a NAS device
+type nasDeviceResponse struct {
+	Id                 string `json:"Id"`
+	Name               string `json:"Name"`
+	IpAddress          string `json:"IpAddress"`
+	Vendor             string `json:"Vendor"`
+	Description        string `json:"Description"`
+	Site               string `json:"Site"`
+	SharedSecretSource string `json:"SharedSecretSource"`
+	SharedSecretId     string `json:"SharedSecretId"`
+}
+
+// ResourceNasDevices registers many NAS devices of a site at once, such as the switches of a campus. Devices are
+// created and deleted in batches and identified by name, so only the devices listed here are managed and devices
+// registered in the portal are left alone.
+func ResourceNasDevices() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNasDevicesCreate,
+		ReadContext:   resourceNasDevicesRead,
+		UpdateContext: resourceNasDevicesUpdate,
+		DeleteContext: resourceNasDevicesDelete,
+		CustomizeDiff: resourceNasDevicesCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNasDevicesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"site": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The site the NAS devices are registered in.",
+			},
+			"devices": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true, // Populated from devices_csv when the CSV input is used
+				ExactlyOneOf: []string{"devices", "devices_csv"},
+				Description:  "The NAS devices to register. Device names must be unique.",
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The name of the NAS device.",
+					},
+					"ip_address": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsIPAddress,
+						Description:  "The IP address the NAS device sends RADIUS requests from.",
+					},
+					"vendor": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The vendor of the NAS device, e.g. Cisco or Aruba, used to select vendor-specific RADIUS attributes.",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "A description of the NAS device.",
+					},
+				}},
+			},
+			"devices_csv": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "An alternative to devices: CSV content with one name,ip_address,vendor,description row per device, e.g. exported from an IPAM. Only the name and ip_address columns are required, and a header row is optional.",
+				ValidateFunc: validateNasDevicesCSV,
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "The number of devices registered or deleted per API request.",
+			},
+			"device_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the registered NAS devices, keyed by device name.",
+			},
+			"device_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of NAS devices managed by this resource.",
+			},
+		},
+	}
+}
+
+// parseNasDevicesCSV expands CSV content of name,ip_address,vendor,description rows into devices entries. All
+// columns but name and ip_address are optional, and a leading header row is skipped.
+func parseNasDevicesCSV(content string) ([]interface{}, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing devices_csv: %s", err)
+	}
+
+	devices := make([]interface{}, 0, len(records))
+	for i, record := range records {
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+
+		// Skip the header row if present
+		if i == 0 && strings.ToLower(strings.TrimSpace(record[0])) == "name" {
+			continue
+		}
+
+		if len(record) < 2 || len(record) > 4 {
+			return nil, fmt.Errorf("devices_csv line %d: expected 2 to 4 columns (name,ip_address,vendor,description), got %d", i+1, len(record))
+		}
+
+		device := map[string]interface{}{
+			"name":        strings.TrimSpace(record[0]),
+			"ip_address":  strings.TrimSpace(record[1]),
+			"vendor":      "",
+			"description": "",
+		}
+		if len(record) > 2 {
+			device["vendor"] = strings.TrimSpace(record[2])
+		}
+		if len(record) > 3 {
+			device["description"] = strings.TrimSpace(record[3])
+		}
+
+		if device["name"].(string) == "" {
+			return nil, fmt.Errorf("devices_csv line %d: name must not be empty", i+1)
+		}
+		if net.ParseIP(device["ip_address"].(string)) == nil {
+			return nil, fmt.Errorf("devices_csv line %d: %q is not a valid IP address", i+1, device["ip_address"])
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices, nil
+}
+
+func validateNasDevicesCSV(v interface{}, k string) ([]string, []error) {
+	if _, err := parseNasDevicesCSV(v.(string)); err != nil {
+		return nil, []error{err}
+	}
+	return nil, nil
+}
+
+// expandNasDevices returns the configured devices, expanding devices_csv if it is used instead
+func expandNasDevices(d *schema.ResourceData) ([]interface{}, error) {
+	if csvContent, ok := d.GetOk("devices_csv"); ok {
+		return parseNasDevicesCSV(csvContent.(string))
+	}
+	if devices, ok := d.GetOk("devices"); ok {
+		return devices.([]interface{}), nil
+	}
+	return []interface{}{}, nil
+}
+
+// nasDevicesByName indexes device entries by name
+func nasDevicesByName(devices []interface{}) map[string]map[string]interface{} {
+	byName := make(map[string]map[string]interface{}, len(devices))
+	for _, device := range devices {
+		if deviceMap, ok := device.(map[string]interface{}); ok {
+			byName[deviceMap["name"].(string)] = deviceMap
+		}
+	}
+	return byName
+}
+
+// resourceNasDevicesCustomizeDiff plans devices from devices_csv, so changes to the CSV and devices deleted outside
+// of Terraform show up in the plan, and rejects duplicate device names
+func resourceNasDevicesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("devices_csv") {
+		return nil
+	}
+
+	csvContent := d.Get("devices_csv").(string)
+	var devices []interface{}
+	if csvContent != "" {
+		parsed, err := parseNasDevicesCSV(csvContent)
+		if err != nil {
+			return err
+		}
+		devices = parsed
+	} else if d.NewValueKnown("devices") {
+		devices = d.Get("devices").([]interface{})
+	}
+
+	seen := make(map[string]bool, len(devices))
+	for _, device := range devices {
+		name := device.(map[string]interface{})["name"].(string)
+		if seen[name] {
+			return fmt.Errorf("device name %q is listed more than once", name)
+		}
+		seen[name] = true
+	}
+
+	if csvContent != "" {
+		return d.SetNew("devices", devices)
+	}
+	return nil
+}
+
+// nasDeviceEntryPayload builds the API representation of a NAS device entry
+func nasDeviceEntryPayload(site string, device map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"Name":        device["name"].(string),
+		"IpAddress":   device["ip_address"].(string),
+		"Vendor":      device["vendor"].(string),
+		"Description": device["description"].(string),
+		"Site":        site,
+	}
+}
+
+// createNasDevices registers devices in batches and returns the IDs of the ones created, keyed by name, with the
+// reasons the API gave for the ones it rejected. A failed request stops the remaining batches.
+func createNasDevices(ctx context.Context, config *common.Config, site string, devices []map[string]interface{}, batchSize int) (map[string]string, map[string]string, error) {
+	created := make(map[string]string)
+	failures := make(map[string]string)
+
+	for start := 0; start < len(devices); start += batchSize {
+		end := min(start+batchSize, len(devices))
+		batch := make([]map[string]interface{}, 0, end-start)
+		for _, device := range devices[start:end] {
+			batch = append(batch, nasDeviceEntryPayload(site, device))
+		}
+
+		responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/nas-devices/bulk", map[string]interface{}{
+			"Devices": batch,
+		})
+		if err != nil {
+			return created, failures, fmt.Errorf("registering devices %d to %d of %d failed: %w", start+1, end, len(devices), err)
+		}
+
+		var response struct {
+			Results []struct {
+				Name   string `json:"Name"`
+				Id     string `json:"Id"`
+				Status string `json:"Status"`
+				Error  string `json:"Error"`
+			} `json:"Results"`
+		}
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return created, failures, err
+		}
+		for _, result := range response.Results {
+			if result.Id == "" || strings.EqualFold(result.Status, "failed") {
+				failures[result.Name] = result.Error
+				continue
+			}
+			created[result.Name] = result.Id
+		}
+	}
+
+	return created, failures, nil
+}
+
+// deleteNasDevices deletes devices by ID in batches
+func deleteNasDevices(ctx context.Context, config *common.Config, ids []string, batchSize int) error {
+	for start := 0; start < len(ids); start += batchSize {
+		end := min(start+batchSize, len(ids))
+		if _, err := config.MakeRequestWithRetry(ctx, "POST", "/api/nas-devices/bulk-delete", map[string]interface{}{
+			"Ids": ids[start:end],
+		}); err != nil && !config.IsNotFoundError(err) {
+			return fmt.Errorf("deleting devices %d to %d of %d failed: %w", start+1, end, len(ids), err)
+		}
+	}
+	return nil
+}
+
+// nasDeviceFailureDiagnostics reports each device the API rejected as its own error
+func nasDeviceFailureDiagnostics(failures map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for name, reason := range failures {
+		if reason == "" {
+			reason = "rejected by the API"
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("NAS device %s was not registered", name),
+			Detail:   reason,
+		})
+	}
+	return diags
+}
+
+func resourceNasDevicesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	site := d.Get("site").(string)
+	expanded, err := expandNasDevices(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	devices := make([]map[string]interface{}, 0, len(expanded))
+	for _, device := range expanded {
+		devices = append(devices, device.(map[string]interface{}))
+	}
+
+	created, failures, err := createNasDevices(ctx, config, site, devices, d.Get("batch_size").(int))
+
+	// Track the devices that were created even if others failed, so they are not registered twice
+	d.SetId(site)
+	d.Set("device_ids", created)
+	if err != nil {
+		return apiErrorDiagnostics(err, "devices")
+	}
+	if len(failures) > 0 {
+		return nasDeviceFailureDiagnostics(failures)
+	}
+
+	return resourceNasDevicesRead(ctx, d, m)
+}
+
+func resourceNasDevicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/nas-devices?"+url.Values{"site": {d.Id()}}.Encode(), nil)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var registered []nasDeviceResponse
+	if err := json.Unmarshal(responseBody, &registered); err != nil {
+		return diag.FromErr(err)
+	}
+	byID := make(map[string]nasDeviceResponse, len(registered))
+	for _, device := range registered {
+		byID[device.Id] = device
+	}
+
+	// Keep the order of the configuration and drop the devices deleted outside of Terraform, which the next apply
+	// registers again
+	deviceIDs := d.Get("device_ids").(map[string]interface{})
+	ids := make(map[string]string)
+	devices := make([]map[string]interface{}, 0, len(deviceIDs))
+	for _, device := range d.Get("devices").([]interface{}) {
+		name := device.(map[string]interface{})["name"].(string)
+		id, _ := deviceIDs[name].(string)
+		current, ok := byID[id]
+		if !ok {
+			continue
+		}
+		devices = append(devices, map[string]interface{}{
+			"name":        current.Name,
+			"ip_address":  current.IpAddress,
+			"vendor":      current.Vendor,
+			"description": current.Description,
+		})
+		ids[current.Name] = id
+	}
+
+	d.Set("site", d.Id())
+	if err := d.Set("devices", devices); err != nil {
+		return diag.Errorf("error setting devices: %s", err)
+	}
+	d.Set("device_ids", ids)
+	d.Set("device_count", len(ids))
+
+	return nil
+}
+
+// resourceNasDevicesImport imports all the NAS devices registered in a site, which are then managed by the resource
+func resourceNasDevicesImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := importStateID("the name of the site", ResourceNasDevices)(ctx, d, m); err != nil {
+		return nil, err
+	}
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/nas-devices?"+url.Values{"site": {d.Id()}}.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving the NAS devices of site %s: %s", d.Id(), err)
+	}
+	var registered []nasDeviceResponse
+	if err := json.Unmarshal(responseBody, &registered); err != nil {
+		return nil, fmt.Errorf("error parsing API response: %s", err)
+	}
+	if len(registered) == 0 {
+		return nil, fmt.Errorf("site %s has no NAS devices to import", d.Id())
+	}
+	sort.Slice(registered, func(i, j int) bool { return registered[i].Name < registered[j].Name })
+
+	devices := make([]map[string]interface{}, 0, len(registered))
+	ids := make(map[string]string, len(registered))
+	for _, device := range registered {
+		if _, duplicate := ids[device.Name]; duplicate {
+			return nil, fmt.Errorf("site %s has more than one NAS device named %s, which the resource identifies devices by", d.Id(), device.Name)
+		}
+		devices = append(devices, map[string]interface{}{
+			"name":        device.Name,
+			"ip_address":  device.IpAddress,
+			"vendor":      device.Vendor,
+			"description": device.Description,
+		})
+		ids[device.Name] = device.Id
+	}
+
+	if err := d.Set("devices", devices); err != nil {
+		return nil, fmt.Errorf("error setting devices: %s", err)
+	}
+	d.Set("device_ids", ids)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceNasDevicesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	site := d.Id()
+	batchSize := d.Get("batch_size").(int)
+
+	expanded, err := expandNasDevices(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldDevices, _ := d.GetChange("devices")
+	previous := nasDevicesByName(oldDevices.([]interface{}))
+	desired := nasDevicesByName(expanded)
+
+	ids := make(map[string]string)
+	for name, id := range d.Get("device_ids").(map[string]interface{}) {
+		ids[name] = id.(string)
+	}
+
+	// Delete the removed devices first, so a replacement can reuse their IP address
+	var removed []string
+	for name, id := range ids {
+		if _, ok := desired[name]; !ok {
+			removed = append(removed, id)
+			delete(ids, name)
+		}
+	}
+	if err := deleteNasDevices(ctx, config, removed, batchSize); err != nil {
+		return apiErrorDiagnostics(err, "devices")
+	}
+
+	var added []map[string]interface{}
+	for _, device := range expanded {
+		deviceMap := device.(map[string]interface{})
+		name := deviceMap["name"].(string)
+		id, exists := ids[name]
+		if !exists {
+			added = append(added, deviceMap)
+			continue
+		}
+		if old, ok := previous[name]; ok && nasDeviceUnchanged(old, deviceMap) {
+			continue
+		}
+		if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/nas-devices/"+id, nasDeviceEntryPayload(site, deviceMap)); err != nil {
+			d.Set("device_ids", ids)
+			return apiErrorDiagnostics(err, "devices")
+		}
+	}
+
+	created, failures, err := createNasDevices(ctx, config, site, added, batchSize)
+	for name, id := range created {
+		ids[name] = id
+	}
+	d.Set("device_ids", ids)
+	if err != nil {
+		return apiErrorDiagnostics(err, "devices")
+	}
+	if len(failures) > 0 {
+		return nasDeviceFailureDiagnostics(failures)
+	}
+
+	return resourceNasDevicesRead(ctx, d, m)
+}
+
+// nasDeviceUnchanged reports whether two device entries have the same settings
+func nasDeviceUnchanged(old, current map[string]interface{}) bool {
+	for _, key := range []string{"ip_address", "vendor", "description"} {
+		if old[key] != current[key] {
+			return false
+		}
+	}
+	return true
+}
+
+func resourceNasDevicesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	ids := make([]string, 0)
+	for _, id := range d.Get("device_ids").(map[string]interface{}) {
+		ids = append(ids, id.(string))
+	}
+	if err := deleteNasDevices(ctx, config, ids, d.Get("batch_size").(int)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	d.SetId("")
+
+	return nil
+}
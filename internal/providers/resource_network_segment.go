@@ -0,0 +1,145 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// networkSegmentResponse is the API representation of a network segment
+type networkSegmentResponse struct {
+	Id          string   `json:"Id"`
+	Name        string   `json:"Name"`
+	Description string   `json:"Description"`
+	Subnets     []string `json:"Subnets"`
+	Vlan        string   `json:"Vlan"`
+	Location    string   `json:"Location"`
+}
+
+// ResourceNetworkSegment manages a named set of subnets that access policies target
+func ResourceNetworkSegment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkSegmentCreate,
+		ReadContext:   resourceNetworkSegmentRead,
+		UpdateContext: resourceNetworkSegmentUpdate,
+		DeleteContext: resourceNetworkSegmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the network segment", ResourceNetworkSegment),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the network segment.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the network segment.",
+			},
+			"subnets": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsCIDR},
+				Description: "The subnets of the segment in CIDR notation, e.g. 10.20.0.0/16.",
+			},
+			"vlan": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The VLAN ID or name associated with the segment.",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The site or location the segment belongs to.",
+			},
+		},
+	}
+}
+
+// networkSegmentPayload builds the API representation of the network segment from the resource data
+func networkSegmentPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"Name":        d.Get("name").(string),
+		"Description": d.Get("description").(string),
+		"Subnets":     expandStringList(d.Get("subnets").(*schema.Set).List()),
+		"Vlan":        d.Get("vlan").(string),
+		"Location":    d.Get("location").(string),
+	}
+}
+
+func resourceNetworkSegmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/network-segments", networkSegmentPayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var segment networkSegmentResponse
+	if err := json.Unmarshal(responseBody, &segment); err != nil {
+		return diag.FromErr(err)
+	}
+	if segment.Id == "" {
+		return diag.Errorf("the API did not return an ID for network segment %s", d.Get("name").(string))
+	}
+
+	d.SetId(segment.Id)
+
+	return resourceNetworkSegmentRead(ctx, d, m)
+}
+
+func resourceNetworkSegmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/network-segments/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_network_segment", "network segment not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var segment networkSegmentResponse
+	if err := json.Unmarshal(responseBody, &segment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", segment.Name)
+	d.Set("description", segment.Description)
+	d.Set("subnets", segment.Subnets)
+	d.Set("vlan", segment.Vlan)
+	d.Set("location", segment.Location)
+
+	return nil
+}
+
+func resourceNetworkSegmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/network-segments/"+d.Id(), networkSegmentPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceNetworkSegmentRead(ctx, d, m)
+}
+
+func resourceNetworkSegmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/network-segments/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
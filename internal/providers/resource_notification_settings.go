@@ -0,0 +1,244 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// notificationSettingsID is the ID of the organization-wide notification settings, of which there is exactly one
+const notificationSettingsID = "notifications"
+
+// ResourceNotificationSettings manages how the organization delivers notification emails. Creating the resource
+// adopts the existing settings, and destroying it restores the built-in delivery with all templates enabled.
+func ResourceNotificationSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNotificationSettingsCreate,
+		ReadContext:   resourceNotificationSettingsRead,
+		UpdateContext: resourceNotificationSettingsUpdate,
+		DeleteContext: resourceNotificationSettingsDelete,
+		CustomizeDiff: resourceNotificationSettingsCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateFixedID(notificationSettingsID, ResourceNotificationSettings),
+		},
+		Schema: map[string]*schema.Schema{
+			"sender_address": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The From address of notification emails. Defaults to the Portnox address when unset.",
+			},
+			"sender_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The display name of the sender of notification emails.",
+			},
+			"delivery": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "builtin",
+				ValidateFunc: validation.StringInSlice([]string{"builtin", "smtp"}, false),
+				Description:  "How notification emails are sent. One of builtin, or smtp to relay through the server configured in smtp.",
+			},
+			"smtp": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The host name of the SMTP relay.",
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      587,
+							ValidateFunc: validation.IsPortNumber,
+							Description:  "The port of the SMTP relay.",
+						},
+						"security": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "starttls",
+							ValidateFunc: validation.StringInSlice([]string{"none", "starttls", "tls"}, false),
+							Description:  "The connection security of the SMTP relay. One of none, starttls, or tls.",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The user name to authenticate to the SMTP relay with.",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The password to authenticate to the SMTP relay with. The API never returns the password, so changes made outside of Terraform are not detected.",
+						},
+						"password_wo": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							WriteOnly:   true,
+							Description: "The password to authenticate to the SMTP relay with as a write-only argument, which is never stored in state. Requires Terraform 1.11 or later. The password is only sent on create and when password_wo_version changes.",
+						},
+						"password_wo_version": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "A version number for password_wo. Change it to send the current password_wo value.",
+						},
+					},
+				},
+				Description: "The SMTP relay used when delivery is smtp.",
+			},
+			"enabled_templates": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The notification templates that are sent, e.g. device_blocked or account_expiring. When unset, the current templates are kept.",
+			},
+		},
+	}
+}
+
+func resourceNotificationSettingsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("delivery").(string) == "smtp" && d.NewValueKnown("smtp") && len(d.Get("smtp").([]interface{})) == 0 {
+		return fmt.Errorf("smtp is required when delivery is %q", "smtp")
+	}
+	return nil
+}
+
+// notificationSettingsPayload builds the API representation of the notification settings from the resource data
+func notificationSettingsPayload(d *schema.ResourceData) (map[string]interface{}, diag.Diagnostics) {
+	payload := map[string]interface{}{
+		"SenderAddress": d.Get("sender_address").(string),
+		"SenderName":    d.Get("sender_name").(string),
+		"Delivery":      d.Get("delivery").(string),
+	}
+
+	if smtp := d.Get("smtp").([]interface{}); len(smtp) > 0 && smtp[0] != nil {
+		relay := smtp[0].(map[string]interface{})
+		smtpPayload := map[string]interface{}{
+			"Host":     relay["host"].(string),
+			"Port":     relay["port"].(int),
+			"Security": relay["security"].(string),
+			"Username": relay["username"].(string),
+		}
+		// Only send the password when it is set or changed, so updates do not reset it
+		password, changed, diags := changedSecret(d, "smtp.0.password", "smtp.0.password_wo", "smtp.0.password_wo_version")
+		if diags.HasError() {
+			return nil, diags
+		}
+		if changed {
+			smtpPayload["Password"] = password
+		}
+		payload["Smtp"] = smtpPayload
+	}
+
+	if templates, ok := d.GetOk("enabled_templates"); ok {
+		payload["EnabledTemplates"] = expandStringList(templates.(*schema.Set).List())
+	}
+
+	return payload, nil
+}
+
+func resourceNotificationSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := notificationSettingsPayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/notification-settings", payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	d.SetId(notificationSettingsID)
+
+	return resourceNotificationSettingsRead(ctx, d, m)
+}
+
+func resourceNotificationSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/notification-settings", nil)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var settings struct {
+		SenderAddress string `json:"SenderAddress"`
+		SenderName    string `json:"SenderName"`
+		Delivery      string `json:"Delivery"`
+		Smtp          *struct {
+			Host     string `json:"Host"`
+			Port     int    `json:"Port"`
+			Security string `json:"Security"`
+			Username string `json:"Username"`
+		} `json:"Smtp"`
+		EnabledTemplates []string `json:"EnabledTemplates"`
+	}
+	if err := json.Unmarshal(responseBody, &settings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("sender_address", settings.SenderAddress)
+	d.Set("sender_name", settings.SenderName)
+	d.Set("delivery", settings.Delivery)
+	d.Set("enabled_templates", settings.EnabledTemplates)
+
+	smtp := make([]interface{}, 0, 1)
+	if settings.Smtp != nil && settings.Smtp.Host != "" {
+		smtp = append(smtp, map[string]interface{}{
+			"host":     settings.Smtp.Host,
+			"port":     settings.Smtp.Port,
+			"security": settings.Smtp.Security,
+			"username": settings.Smtp.Username,
+			// The API never returns the password, so keep the configured one
+			"password":            d.Get("smtp.0.password").(string),
+			"password_wo_version": d.Get("smtp.0.password_wo_version").(int),
+		})
+	}
+	d.Set("smtp", smtp)
+
+	return nil
+}
+
+func resourceNotificationSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	payload, diags := notificationSettingsPayload(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/notification-settings", payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceNotificationSettingsRead(ctx, d, m)
+}
+
+func resourceNotificationSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	// The settings cannot be deleted, so restore the built-in delivery instead
+	payload := map[string]interface{}{
+		"Delivery": "builtin",
+	}
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/notification-settings", payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourcePolicyAssignment binds an authentication, access, or risk policy to a group or site. Assignments are
+// separate from the policy and the target, so the order in which a policy is rolled out is explicit in the plan.
+func ResourcePolicyAssignment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePolicyAssignmentCreate,
+		ReadContext:   resourcePolicyAssignmentRead,
+		UpdateContext: resourcePolicyAssignmentUpdate,
+		DeleteContext: resourcePolicyAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the assignment", ResourcePolicyAssignment),
+		},
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the authentication, access, or risk policy to assign.",
+			},
+			"target_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"group", "site"}, false),
+				Description:  "The type of object the policy is assigned to: group or site.",
+			},
+			"target_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the group or site the policy is assigned to.",
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The evaluation order of the assignment among the assignments of the same target, lowest first. Assigned by the API when not set.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Indicates whether the assignment is enforced. Disabling it keeps the assignment for a later rollout.",
+			},
+			"schedule_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of an access schedule that limits when the assignment is enforced. When not set, it is enforced at all times.",
+			},
+			"policy_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the assigned policy: authentication, access, or risk.",
+			},
+		},
+	}
+}
+
+// policyAssignmentPayload builds the API representation of the policy assignment from the resource data
+func policyAssignmentPayload(d *schema.ResourceData) map[string]interface{} {
+	payload := map[string]interface{}{
+		"PolicyId":   d.Get("policy_id").(string),
+		"TargetType": d.Get("target_type").(string),
+		"TargetId":   d.Get("target_id").(string),
+		"Enabled":    d.Get("enabled").(bool),
+		"ScheduleId": d.Get("schedule_id").(string),
+	}
+	if priority, ok := d.GetOk("priority"); ok {
+		payload["Priority"] = priority.(int)
+	}
+	return payload
+}
+
+func resourcePolicyAssignmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/policy-assignments", policyAssignmentPayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "policy_id")
+	}
+
+	var assignment struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &assignment); err != nil {
+		return diag.FromErr(err)
+	}
+	if assignment.Id == "" {
+		return diag.Errorf("the API did not return an ID for the assignment of policy %s", d.Get("policy_id").(string))
+	}
+
+	d.SetId(assignment.Id)
+
+	return resourcePolicyAssignmentRead(ctx, d, m)
+}
+
+func resourcePolicyAssignmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/policy-assignments/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_policy_assignment", "policy assignment not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var assignment struct {
+		PolicyId   string `json:"PolicyId"`
+		PolicyType string `json:"PolicyType"`
+		TargetType string `json:"TargetType"`
+		TargetId   string `json:"TargetId"`
+		Priority   int    `json:"Priority"`
+		Enabled    bool   `json:"Enabled"`
+		ScheduleId string `json:"ScheduleId"`
+	}
+	if err := json.Unmarshal(responseBody, &assignment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("policy_id", assignment.PolicyId)
+	d.Set("policy_type", assignment.PolicyType)
+	d.Set("target_type", assignment.TargetType)
+	d.Set("target_id", assignment.TargetId)
+	d.Set("priority", assignment.Priority)
+	d.Set("enabled", assignment.Enabled)
+	d.Set("schedule_id", assignment.ScheduleId)
+
+	return nil
+}
+
+func resourcePolicyAssignmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/policy-assignments/"+d.Id(), policyAssignmentPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourcePolicyAssignmentRead(ctx, d, m)
+}
+
+func resourcePolicyAssignmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/policy-assignments/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
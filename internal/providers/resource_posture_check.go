@@ -0,0 +1,216 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// postureCheckRequiredAttributes lists the attributes each posture check type needs
+var postureCheckRequiredAttributes = map[string][]string{
+	"registry_key":    {"registry_path"},
+	"file_exists":     {"file_path"},
+	"process_running": {"process_name"},
+	"script":          {"script"},
+}
+
+// ResourcePostureCheck manages a custom posture check that risk policies reference
+func ResourcePostureCheck() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePostureCheckCreate,
+		ReadContext:   resourcePostureCheckRead,
+		UpdateContext: resourcePostureCheckUpdate,
+		DeleteContext: resourcePostureCheckDelete,
+		CustomizeDiff: resourcePostureCheckCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the posture check", ResourcePostureCheck),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the posture check.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the posture check.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"registry_key", "file_exists", "process_running", "script"}, false),
+				Description:  "The type of the posture check. One of registry_key, file_exists, process_running, or script.",
+			},
+			"operating_system": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"windows", "macos", "linux"}, false),
+				Description:  "The operating system the posture check applies to. One of windows, macos, or linux.",
+			},
+			"registry_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The registry key checked by a registry_key check, e.g. HKLM\\SOFTWARE\\Vendor\\Agent.",
+			},
+			"registry_value_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The registry value checked by a registry_key check. When unset, only the key must exist.",
+			},
+			"expected_value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The value the registry value or script output must equal for the check to pass.",
+			},
+			"file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The file that must exist for a file_exists check to pass.",
+			},
+			"process_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The process that must be running for a process_running check to pass.",
+			},
+			"script": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The script run by a script check. The check passes when the script exits with 0, or prints expected_value when set.",
+			},
+			"script_interpreter": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"powershell", "bash", "zsh"}, false),
+				Description:  "The interpreter used to run a script check. One of powershell, bash, or zsh.",
+			},
+		},
+	}
+}
+
+func resourcePostureCheckCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	checkType := d.Get("type").(string)
+	for _, attribute := range postureCheckRequiredAttributes[checkType] {
+		if _, ok := d.GetOk(attribute); !ok && d.NewValueKnown(attribute) {
+			return fmt.Errorf("%s is required when type is %q", attribute, checkType)
+		}
+	}
+	if checkType == "script" && d.NewValueKnown("script_interpreter") && d.Get("script_interpreter").(string) == "" {
+		return fmt.Errorf("script_interpreter is required when type is %q", checkType)
+	}
+	return nil
+}
+
+// postureCheckPayload builds the API representation of the posture check from the resource data
+func postureCheckPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"Name":              d.Get("name").(string),
+		"Description":       d.Get("description").(string),
+		"Type":              d.Get("type").(string),
+		"OperatingSystem":   d.Get("operating_system").(string),
+		"RegistryPath":      d.Get("registry_path").(string),
+		"RegistryValueName": d.Get("registry_value_name").(string),
+		"ExpectedValue":     d.Get("expected_value").(string),
+		"FilePath":          d.Get("file_path").(string),
+		"ProcessName":       d.Get("process_name").(string),
+		"Script":            d.Get("script").(string),
+		"ScriptInterpreter": d.Get("script_interpreter").(string),
+	}
+}
+
+func resourcePostureCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/posture-checks", postureCheckPayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var check struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &check); err != nil {
+		return diag.FromErr(err)
+	}
+	if check.Id == "" {
+		return diag.Errorf("the API did not return an ID for posture check %s", d.Get("name").(string))
+	}
+
+	d.SetId(check.Id)
+
+	return resourcePostureCheckRead(ctx, d, m)
+}
+
+func resourcePostureCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/posture-checks/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_posture_check", "posture check not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var check struct {
+		Name              string `json:"Name"`
+		Description       string `json:"Description"`
+		Type              string `json:"Type"`
+		OperatingSystem   string `json:"OperatingSystem"`
+		RegistryPath      string `json:"RegistryPath"`
+		RegistryValueName string `json:"RegistryValueName"`
+		ExpectedValue     string `json:"ExpectedValue"`
+		FilePath          string `json:"FilePath"`
+		ProcessName       string `json:"ProcessName"`
+		Script            string `json:"Script"`
+		ScriptInterpreter string `json:"ScriptInterpreter"`
+	}
+	if err := json.Unmarshal(responseBody, &check); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", check.Name)
+	d.Set("description", check.Description)
+	d.Set("type", check.Type)
+	d.Set("operating_system", check.OperatingSystem)
+	d.Set("registry_path", check.RegistryPath)
+	d.Set("registry_value_name", check.RegistryValueName)
+	d.Set("expected_value", check.ExpectedValue)
+	d.Set("file_path", check.FilePath)
+	d.Set("process_name", check.ProcessName)
+	d.Set("script", check.Script)
+	d.Set("script_interpreter", check.ScriptInterpreter)
+
+	return nil
+}
+
+func resourcePostureCheckUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/posture-checks/"+d.Id(), postureCheckPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourcePostureCheckRead(ctx, d, m)
+}
+
+func resourcePostureCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/posture-checks/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
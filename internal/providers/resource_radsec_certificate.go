@@ -0,0 +1,225 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	radsecCertificateTypeCA     = "ca"
+	radsecCertificateTypeClient = "client"
+)
+
+// ResourceRadsecCertificate manages RadSec trust configuration. A ca certificate uploads a trusted CA, while
+// a client certificate is generated by Portnox and its private key is only returned on creation.
+// Certificates are immutable, so every argument forces a new certificate; rotation_trigger rotates on demand.
+func ResourceRadsecCertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRadsecCertificateCreate,
+		ReadContext:   resourceRadsecCertificateRead,
+		DeleteContext: resourceRadsecCertificateDelete,
+		CustomizeDiff: resourceRadsecCertificateCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRadsecCertificateImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the certificate.",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{radsecCertificateTypeCA, radsecCertificateTypeClient}, false),
+				Description:  "The certificate type: ca to upload a trusted CA certificate, or client to generate a RadSec client certificate.",
+			},
+			"certificate_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The PEM-encoded certificate. Required for ca certificates; computed for generated client certificates.",
+			},
+			"validity_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      365,
+				ValidateFunc: validation.IntBetween(1, 3650),
+				Description:  "The validity period of a generated client certificate, in days.",
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "An arbitrary value that generates a new certificate whenever it changes, for scheduled rotation.",
+			},
+			"private_key_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The PEM-encoded private key of a generated client certificate. Only returned by the API on creation.",
+			},
+			"serial_number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The serial number of the certificate.",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 fingerprint of the certificate.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The expiration timestamp of the certificate.",
+			},
+		},
+	}
+}
+
+func resourceRadsecCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	certificateType := d.Get("type").(string)
+	_, hasCertificate := d.GetOk("certificate_pem")
+
+	// Only validate what the user configured; a computed certificate_pem is expected for client certificates
+	if certificateType == radsecCertificateTypeCA && !hasCertificate && d.NewValueKnown("certificate_pem") {
+		return fmt.Errorf("certificate_pem is required when type is %q", radsecCertificateTypeCA)
+	}
+	if certificateType == radsecCertificateTypeClient && d.Id() == "" && hasCertificate {
+		return fmt.Errorf("certificate_pem cannot be set when type is %q, the certificate is generated by Portnox", radsecCertificateTypeClient)
+	}
+
+	return nil
+}
+
+func resourceRadsecCertificateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	certificateType := d.Get("type").(string)
+
+	var endpoint string
+	payload := map[string]interface{}{
+		"Name": d.Get("name").(string),
+	}
+	if certificateType == radsecCertificateTypeCA {
+		endpoint = "/api/radsec/certificates/ca"
+		payload["CertificatePem"] = d.Get("certificate_pem").(string)
+	} else {
+		endpoint = "/api/radsec/certificates/client"
+		payload["ValidityDays"] = d.Get("validity_days").(int)
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", endpoint, payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "certificate_pem")
+	}
+
+	var certificate struct {
+		Id             string `json:"Id"`
+		CertificatePem string `json:"CertificatePem"`
+		PrivateKeyPem  string `json:"PrivateKeyPem"`
+	}
+	if err := json.Unmarshal(responseBody, &certificate); err != nil {
+		return diag.FromErr(err)
+	}
+	if certificate.Id == "" {
+		return diag.Errorf("the API did not return an ID for certificate %s", d.Get("name").(string))
+	}
+
+	d.SetId(certificate.Id)
+	if certificate.CertificatePem != "" {
+		d.Set("certificate_pem", certificate.CertificatePem)
+	}
+	// The private key is never returned again, so it is only captured here
+	d.Set("private_key_pem", certificate.PrivateKeyPem)
+
+	return resourceRadsecCertificateRead(ctx, d, m)
+}
+
+func resourceRadsecCertificateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/radsec/certificates/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_radsec_certificate", "certificate not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var certificate struct {
+		Name         string `json:"Name"`
+		SerialNumber string `json:"SerialNumber"`
+		Fingerprint  string `json:"Fingerprint"`
+		ExpiresAt    string `json:"ExpiresAt"`
+	}
+	if err := json.Unmarshal(responseBody, &certificate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", certificate.Name)
+	d.Set("serial_number", certificate.SerialNumber)
+	d.Set("fingerprint", certificate.Fingerprint)
+	d.Set("expires_at", certificate.ExpiresAt)
+
+	return nil
+}
+
+// resourceRadsecCertificateImport imports a certificate by ID. The arguments that are only sent when the certificate is
+// created, and so are not refreshed by the read, are set from the certificate so the imported state matches it. The
+// private key of a client certificate is only returned on creation and cannot be imported.
+func resourceRadsecCertificateImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := importStateID("the ID of the certificate", ResourceRadsecCertificate)(ctx, d, m); err != nil {
+		return nil, err
+	}
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/radsec/certificates/"+d.Id(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving RadSec certificate %s: %s", d.Id(), err)
+	}
+
+	var certificate struct {
+		Type           string `json:"Type"`
+		CertificatePem string `json:"CertificatePem"`
+	}
+	if err := json.Unmarshal(responseBody, &certificate); err != nil {
+		return nil, fmt.Errorf("error parsing API response: %s", err)
+	}
+
+	certificateType := strings.ToLower(certificate.Type)
+	if certificateType != radsecCertificateTypeCA && certificateType != radsecCertificateTypeClient {
+		return nil, fmt.Errorf("RadSec certificate %s has unknown type %q", d.Id(), certificate.Type)
+	}
+	d.Set("type", certificateType)
+	d.Set("certificate_pem", certificate.CertificatePem)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRadsecCertificateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/radsec/certificates/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var restRequestMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// ResourceRestRequest performs arbitrary authenticated calls against the Portnox API for endpoints the provider
+// does not model yet. Paths for read, update and destroy may reference the created object with {id}.
+func ResourceRestRequest() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRestRequestCreate,
+		ReadContext:   resourceRestRequestRead,
+		UpdateContext: resourceRestRequestUpdate,
+		DeleteContext: resourceRestRequestDelete,
+		CustomizeDiff: resourceRestRequestCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The API path called on create, relative to the provider base_url (e.g. /api/mac-based-accounts).",
+			},
+			"method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "POST",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(restRequestMethods, false),
+				Description:  "The HTTP method used on create.",
+			},
+			"body": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The JSON request body sent on create and update.",
+			},
+			"id_attribute": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The top-level attribute of the create response used as the resource ID. Defaults to the path.",
+			},
+			"read_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The API path read with GET on refresh. When unset, the resource is not refreshed.",
+			},
+			"update_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(restRequestMethods, false),
+				Description:  "The HTTP method used to send a changed body. When unset, changing the body recreates the resource.",
+			},
+			"update_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The API path called on update. Defaults to read_path, then path.",
+			},
+			"destroy_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DELETE",
+				ValidateFunc: validation.StringInSlice(restRequestMethods, false),
+				Description:  "The HTTP method used on destroy.",
+			},
+			"destroy_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The API path called on destroy. When unset, destroying the resource only removes it from state.",
+			},
+			"destroy_body": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The JSON request body sent on destroy.",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The raw response body of the last create or update call.",
+			},
+			"read_response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The raw response body of the last read_path call.",
+			},
+		},
+	}
+}
+
+// restRequestPayload decodes a JSON body attribute so it is sent verbatim, or returns nil when unset
+func restRequestPayload(body string) (interface{}, error) {
+	if body == "" {
+		return nil, nil
+	}
+	var payload interface{}
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		return nil, fmt.Errorf("error parsing request body: %s", err)
+	}
+	return payload, nil
+}
+
+// restRequestPath substitutes the resource ID for {id} in a configured path
+func restRequestPath(path, id string) string {
+	return strings.ReplaceAll(path, "{id}", id)
+}
+
+func resourceRestRequestCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.HasChange("body") && d.Get("update_method").(string) == "" {
+		return d.ForceNew("body")
+	}
+	return nil
+}
+
+func resourceRestRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	path := d.Get("path").(string)
+	payload, err := restRequestPayload(d.Get("body").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, d.Get("method").(string), path, payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "body")
+	}
+
+	id := path
+	if idAttribute := d.Get("id_attribute").(string); idAttribute != "" {
+		var response map[string]interface{}
+		if err := json.Unmarshal(responseBody, &response); err != nil {
+			return diag.Errorf("error parsing create response to read id_attribute %s: %s", idAttribute, err)
+		}
+		value, ok := response[idAttribute]
+		if !ok || value == nil {
+			return diag.Errorf("id_attribute %s not found in create response", idAttribute)
+		}
+		id = fmt.Sprintf("%v", value)
+	}
+
+	d.SetId(id)
+	d.Set("response", string(responseBody))
+
+	return resourceRestRequestRead(ctx, d, m)
+}
+
+func resourceRestRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	readPath := d.Get("read_path").(string)
+	if readPath == "" {
+		return nil
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", restRequestPath(readPath, d.Id()), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_rest_request", "read_path returned not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	d.Set("read_response", string(responseBody))
+
+	return nil
+}
+
+func resourceRestRequestUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	updateMethod := d.Get("update_method").(string)
+	if d.HasChange("body") && updateMethod != "" {
+		updatePath := d.Get("update_path").(string)
+		if updatePath == "" {
+			updatePath = d.Get("read_path").(string)
+		}
+		if updatePath == "" {
+			updatePath = d.Get("path").(string)
+		}
+
+		payload, err := restRequestPayload(d.Get("body").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		responseBody, err := config.MakeRequestWithRetry(ctx, updateMethod, restRequestPath(updatePath, d.Id()), payload)
+		if err != nil {
+			return apiErrorDiagnostics(err, "body")
+		}
+		d.Set("response", string(responseBody))
+	}
+
+	return resourceRestRequestRead(ctx, d, m)
+}
+
+func resourceRestRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if destroyPath := d.Get("destroy_path").(string); destroyPath != "" {
+		payload, err := restRequestPayload(d.Get("destroy_body").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if _, err := config.MakeRequestWithRetry(ctx, d.Get("destroy_method").(string), restRequestPath(destroyPath, d.Id()), payload); err != nil {
+			if !config.IsNotFoundError(err) {
+				return apiErrorDiagnostics(err, "")
+			}
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// scimSettingsID is the ID of the organization-wide SCIM settings, of which there is exactly one
+const scimSettingsID = "scim"
+
+// ResourceScimSettings manages the SCIM provisioning settings of the organization and its bearer token. Creating the
+// resource adopts the existing settings, and destroying it disables SCIM provisioning.
+func ResourceScimSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScimSettingsCreate,
+		ReadContext:   resourceScimSettingsRead,
+		UpdateContext: resourceScimSettingsUpdate,
+		DeleteContext: resourceScimSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateFixedID(scimSettingsID, ResourceScimSettings),
+		},
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Indicates whether SCIM provisioning is enabled.",
+			},
+			"default_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ID of the user group provisioned users are added to when no group is mapped.",
+			},
+			"attribute_mappings": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A map of SCIM attribute to Portnox user attribute, e.g. { \"userName\" = \"Username\" }. Unmapped attributes use the Portnox defaults.",
+			},
+			"token_validity_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      365,
+				ValidateFunc: validation.IntBetween(1, 3650),
+				Description:  "The validity period of a generated bearer token, in days. Applies to the next generated token.",
+			},
+			"token_rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value that generates a new bearer token whenever it changes, for scheduled rotation. The previous token stops working.",
+			},
+			"scim_base_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SCIM endpoint URL to configure in the identity provider.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The bearer token the identity provider authenticates with. Only returned by the API when generated.",
+			},
+			"token_expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time the bearer token expires.",
+			},
+		},
+	}
+}
+
+// scimSettingsPayload builds the API representation of the SCIM settings from the resource data
+func scimSettingsPayload(d *schema.ResourceData) map[string]interface{} {
+	attributeMappings := make(map[string]string)
+	for key, value := range d.Get("attribute_mappings").(map[string]interface{}) {
+		attributeMappings[key] = value.(string)
+	}
+
+	return map[string]interface{}{
+		"Enabled":           d.Get("enabled").(bool),
+		"DefaultGroupId":    d.Get("default_group_id").(string),
+		"AttributeMappings": attributeMappings,
+		"TokenValidityDays": d.Get("token_validity_days").(int),
+	}
+}
+
+// generateScimToken generates a new SCIM bearer token, invalidating the previous one
+func generateScimToken(ctx context.Context, config *common.Config, d *schema.ResourceData) diag.Diagnostics {
+	payload := map[string]interface{}{
+		"ValidityDays": d.Get("token_validity_days").(int),
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/scim-settings/token", payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "token_rotation_trigger")
+	}
+
+	var token struct {
+		Token     string `json:"Token"`
+		ExpiresAt string `json:"ExpiresAt"`
+	}
+	if err := json.Unmarshal(responseBody, &token); err != nil {
+		return diag.FromErr(err)
+	}
+	if token.Token == "" {
+		return diag.Errorf("the API did not return a SCIM token")
+	}
+
+	d.Set("token", token.Token)
+	d.Set("token_expires_at", token.ExpiresAt)
+
+	return nil
+}
+
+func resourceScimSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/scim-settings", scimSettingsPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	d.SetId(scimSettingsID)
+
+	if diags := generateScimToken(ctx, config, d); diags.HasError() {
+		return diags
+	}
+
+	return resourceScimSettingsRead(ctx, d, m)
+}
+
+func resourceScimSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/scim-settings", nil)
+	if err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var settings struct {
+		Enabled           bool              `json:"Enabled"`
+		DefaultGroupId    string            `json:"DefaultGroupId"`
+		AttributeMappings map[string]string `json:"AttributeMappings"`
+		TokenValidityDays int               `json:"TokenValidityDays"`
+		ScimBaseUrl       string            `json:"ScimBaseUrl"`
+		TokenExpiresAt    string            `json:"TokenExpiresAt"`
+	}
+	if err := json.Unmarshal(responseBody, &settings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("enabled", settings.Enabled)
+	d.Set("default_group_id", settings.DefaultGroupId)
+	d.Set("scim_base_url", settings.ScimBaseUrl)
+	if settings.TokenValidityDays > 0 {
+		d.Set("token_validity_days", settings.TokenValidityDays)
+	}
+	if settings.TokenExpiresAt != "" {
+		d.Set("token_expires_at", settings.TokenExpiresAt)
+	}
+
+	// Only track the mappings that are configured, as the API also returns the defaults
+	configured := d.Get("attribute_mappings").(map[string]interface{})
+	mappings := make(map[string]string)
+	for key, value := range settings.AttributeMappings {
+		if _, ok := configured[key]; ok {
+			mappings[key] = value
+		}
+	}
+	d.Set("attribute_mappings", mappings)
+
+	return nil
+}
+
+func resourceScimSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if d.HasChanges("enabled", "default_group_id", "attribute_mappings", "token_validity_days") {
+		if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/scim-settings", scimSettingsPayload(d)); err != nil {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	if d.HasChange("token_rotation_trigger") {
+		if diags := generateScimToken(ctx, config, d); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceScimSettingsRead(ctx, d, m)
+}
+
+func resourceScimSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	// The settings cannot be deleted, so disable provisioning instead
+	payload := map[string]interface{}{
+		"Enabled": false,
+	}
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/scim-settings", payload); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceSiteBroker registers an on-premises site broker, the local RADIUS proxy that keeps a site authenticating
+// when its connection to the cloud is degraded, and generates the token the broker enrolls with
+func ResourceSiteBroker() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSiteBrokerCreate,
+		ReadContext:   resourceSiteBrokerRead,
+		UpdateContext: resourceSiteBrokerUpdate,
+		DeleteContext: resourceSiteBrokerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the site broker", ResourceSiteBroker),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the site broker.",
+			},
+			"site": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The site the broker serves.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the site broker.",
+			},
+			"failover_order": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The position of the broker in the failover order of the site, 1 for the primary broker. Assigned by the API when not set.",
+			},
+			"enrollment_token_validity_hours": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      24,
+				ValidateFunc: validation.IntBetween(1, 720),
+				Description:  "The validity period of a generated enrollment token, in hours. Applies to the next generated token.",
+			},
+			"regenerate_token_on": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value that generates a new enrollment token whenever it changes, e.g. to enroll a replacement broker appliance. The previous token stops working.",
+			},
+			"enrollment_token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token the broker enrolls with. Only returned by the API when generated.",
+			},
+			"enrollment_token_expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time the enrollment token expires.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The connection status of the broker, e.g. pending before it has enrolled.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The software version the broker reports, once enrolled.",
+			},
+		},
+	}
+}
+
+// siteBrokerPayload builds the API representation of the site broker from the resource data
+func siteBrokerPayload(d *schema.ResourceData) map[string]interface{} {
+	payload := map[string]interface{}{
+		"Name":        d.Get("name").(string),
+		"Site":        d.Get("site").(string),
+		"Description": d.Get("description").(string),
+	}
+	if failoverOrder, ok := d.GetOk("failover_order"); ok {
+		payload["FailoverOrder"] = failoverOrder.(int)
+	}
+	return payload
+}
+
+// generateSiteBrokerToken generates a new enrollment token for the broker, invalidating the previous one
+func generateSiteBrokerToken(ctx context.Context, config *common.Config, d *schema.ResourceData) diag.Diagnostics {
+	payload := map[string]interface{}{
+		"ValidityHours": d.Get("enrollment_token_validity_hours").(int),
+	}
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/site-brokers/"+d.Id()+"/enrollment-token", payload)
+	if err != nil {
+		return apiErrorDiagnostics(err, "regenerate_token_on")
+	}
+
+	var token struct {
+		Token     string `json:"Token"`
+		ExpiresAt string `json:"ExpiresAt"`
+	}
+	if err := json.Unmarshal(responseBody, &token); err != nil {
+		return diag.FromErr(err)
+	}
+	if token.Token == "" {
+		return diag.Errorf("the API did not return an enrollment token for site broker %s", d.Get("name").(string))
+	}
+
+	d.Set("enrollment_token", token.Token)
+	d.Set("enrollment_token_expires_at", token.ExpiresAt)
+
+	return nil
+}
+
+func resourceSiteBrokerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/site-brokers", siteBrokerPayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var broker struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &broker); err != nil {
+		return diag.FromErr(err)
+	}
+	if broker.Id == "" {
+		return diag.Errorf("the API did not return an ID for site broker %s", d.Get("name").(string))
+	}
+
+	d.SetId(broker.Id)
+
+	if diags := generateSiteBrokerToken(ctx, config, d); diags.HasError() {
+		return diags
+	}
+
+	return resourceSiteBrokerRead(ctx, d, m)
+}
+
+func resourceSiteBrokerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/site-brokers/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_site_broker", "site broker not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var broker struct {
+		Name          string `json:"Name"`
+		Site          string `json:"Site"`
+		Description   string `json:"Description"`
+		FailoverOrder int    `json:"FailoverOrder"`
+		Status        string `json:"Status"`
+		Version       string `json:"Version"`
+	}
+	if err := json.Unmarshal(responseBody, &broker); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", broker.Name)
+	d.Set("site", broker.Site)
+	d.Set("description", broker.Description)
+	d.Set("failover_order", broker.FailoverOrder)
+	d.Set("status", broker.Status)
+	d.Set("version", broker.Version)
+
+	return nil
+}
+
+func resourceSiteBrokerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if d.HasChanges("name", "site", "description", "failover_order") {
+		if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/site-brokers/"+d.Id(), siteBrokerPayload(d)); err != nil {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	if d.HasChange("regenerate_token_on") {
+		if diags := generateSiteBrokerToken(ctx, config, d); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceSiteBrokerRead(ctx, d, m)
+}
+
+func resourceSiteBrokerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/site-brokers/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
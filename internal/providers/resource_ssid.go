@@ -0,0 +1,151 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceSsid() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSsidCreate,
+		ReadContext:   resourceSsidRead,
+		UpdateContext: resourceSsidUpdate,
+		DeleteContext: resourceSsidDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the SSID", ResourceSsid),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The SSID broadcast name of the wireless network.",
+				ValidateFunc: validation.StringLenBetween(1, 32),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the wireless network.",
+			},
+			"security_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The security type of the wireless network. One of open, wpa2-personal, wpa2-enterprise, wpa3-personal, or wpa3-enterprise.",
+				ValidateFunc: validation.StringInSlice([]string{"open", "wpa2-personal", "wpa2-enterprise", "wpa3-personal", "wpa3-enterprise"}, false),
+			},
+			"authentication_policy_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the authentication policies evaluated for clients connecting to this SSID.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Indicates whether cloud RADIUS authentication is enabled for this SSID.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The organization ID associated with the SSID.",
+			},
+		},
+	}
+}
+
+// ssidPayload builds the API representation of the SSID from the resource data
+func ssidPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"SsidName":                d.Get("name").(string),
+		"Description":             d.Get("description").(string),
+		"SecurityType":            d.Get("security_type").(string),
+		"AuthenticationPolicyIds": expandStringList(d.Get("authentication_policy_ids").([]interface{})),
+		"Enabled":                 d.Get("enabled").(bool),
+	}
+}
+
+func resourceSsidCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/ssids", ssidPayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var ssid struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &ssid); err != nil {
+		return diag.FromErr(err)
+	}
+	if ssid.Id == "" {
+		return diag.Errorf("the API did not return an ID for SSID %s", d.Get("name").(string))
+	}
+
+	d.SetId(ssid.Id)
+
+	return resourceSsidRead(ctx, d, m)
+}
+
+func resourceSsidRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/ssids/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_ssid", "SSID not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var ssid struct {
+		SsidName                string   `json:"SsidName"`
+		Description             string   `json:"Description"`
+		SecurityType            string   `json:"SecurityType"`
+		AuthenticationPolicyIds []string `json:"AuthenticationPolicyIds"`
+		Enabled                 bool     `json:"Enabled"`
+		OrgId                   string   `json:"OrgId"`
+	}
+	if err := json.Unmarshal(responseBody, &ssid); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", ssid.SsidName)
+	d.Set("description", ssid.Description)
+	d.Set("security_type", ssid.SecurityType)
+	d.Set("authentication_policy_ids", ssid.AuthenticationPolicyIds)
+	d.Set("enabled", ssid.Enabled)
+	d.Set("org_id", ssid.OrgId)
+
+	return nil
+}
+
+func resourceSsidUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/ssids/"+d.Id(), ssidPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceSsidRead(ctx, d, m)
+}
+
+func resourceSsidDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/ssids/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceUserGroup manages a user group. User groups hold user identities and are distinct from the device
+// groups referenced by the group_id of MAC-based accounts.
+func ResourceUserGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserGroupCreate,
+		ReadContext:   resourceUserGroupRead,
+		UpdateContext: resourceUserGroupUpdate,
+		DeleteContext: resourceUserGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the group", ResourceUserGroup),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the user group.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the user group.",
+			},
+			"member_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the users that are members of the group. When set, membership is authoritative and members added outside of Terraform are removed. When unset, membership is not managed.",
+			},
+		},
+	}
+}
+
+// userGroupPayload builds the API representation of the user group from the resource data
+func userGroupPayload(d *schema.ResourceData) map[string]interface{} {
+	payload := map[string]interface{}{
+		"Name":        d.Get("name").(string),
+		"Description": d.Get("description").(string),
+	}
+
+	// Leave membership untouched unless it is configured
+	if members, ok := d.GetOk("member_ids"); ok || d.HasChange("member_ids") {
+		if ok {
+			payload["MemberIds"] = expandStringList(members.(*schema.Set).List())
+		} else {
+			payload["MemberIds"] = []string{}
+		}
+	}
+
+	return payload
+}
+
+func resourceUserGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/user-groups", userGroupPayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var group struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &group); err != nil {
+		return diag.FromErr(err)
+	}
+	if group.Id == "" {
+		return diag.Errorf("the API did not return an ID for user group %s", d.Get("name").(string))
+	}
+
+	d.SetId(group.Id)
+
+	return resourceUserGroupRead(ctx, d, m)
+}
+
+func resourceUserGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/user-groups/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_user_group", "user group not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var group struct {
+		Name        string   `json:"Name"`
+		Description string   `json:"Description"`
+		MemberIds   []string `json:"MemberIds"`
+	}
+	if err := json.Unmarshal(responseBody, &group); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", group.Name)
+	d.Set("description", group.Description)
+	d.Set("member_ids", group.MemberIds)
+
+	return nil
+}
+
+func resourceUserGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/user-groups/"+d.Id(), userGroupPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceUserGroupRead(ctx, d, m)
+}
+
+func resourceUserGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/user-groups/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// vlanResponse is the API representation of a VLAN catalog entry
+type vlanResponse struct {
+	Id          string `json:"Id"`
+	VlanId      int    `json:"VlanId"`
+	Name        string `json:"Name"`
+	Purpose     string `json:"Purpose"`
+	Description string `json:"Description"`
+}
+
+func ResourceVlan() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVlanCreate,
+		ReadContext:   resourceVlanRead,
+		UpdateContext: resourceVlanUpdate,
+		DeleteContext: resourceVlanDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the VLAN catalog entry", ResourceVlan),
+		},
+		Schema: map[string]*schema.Schema{
+			"vlan_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "The VLAN number, between 1 and 4094.",
+				ValidateFunc: validation.IntBetween(1, 4094),
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The symbolic name of the VLAN, used to reference it from access policies.",
+			},
+			"purpose": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The purpose of the VLAN, such as voice, guest, quarantine, or iot.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the VLAN.",
+			},
+		},
+	}
+}
+
+// vlanPayload builds the API representation of the VLAN from the resource data
+func vlanPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"VlanId":      d.Get("vlan_id").(int),
+		"Name":        d.Get("name").(string),
+		"Purpose":     d.Get("purpose").(string),
+		"Description": d.Get("description").(string),
+	}
+}
+
+func resourceVlanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/vlans", vlanPayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "vlan_id")
+	}
+
+	var vlan vlanResponse
+	if err := json.Unmarshal(responseBody, &vlan); err != nil {
+		return diag.FromErr(err)
+	}
+	if vlan.Id == "" {
+		return diag.Errorf("the API did not return an ID for VLAN %s", d.Get("name").(string))
+	}
+
+	d.SetId(vlan.Id)
+
+	return resourceVlanRead(ctx, d, m)
+}
+
+func resourceVlanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/vlans/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_vlan", "VLAN not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var vlan vlanResponse
+	if err := json.Unmarshal(responseBody, &vlan); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("vlan_id", vlan.VlanId)
+	d.Set("name", vlan.Name)
+	d.Set("purpose", vlan.Purpose)
+	d.Set("description", vlan.Description)
+
+	return nil
+}
+
+func resourceVlanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/vlans/"+d.Id(), vlanPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceVlanRead(ctx, d, m)
+}
+
+func resourceVlanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/vlans/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceZtnaApplication manages an internal application published through Portnox ZTNA
+func ResourceZtnaApplication() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZtnaApplicationCreate,
+		ReadContext:   resourceZtnaApplicationRead,
+		UpdateContext: resourceZtnaApplicationUpdate,
+		DeleteContext: resourceZtnaApplicationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateID("the ID of the ZTNA application", ResourceZtnaApplication),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the application.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of the application.",
+			},
+			"internal_address": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The internal host name or IP address of the application.",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "The port the application listens on.",
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"http", "https", "rdp", "ssh", "tcp"}, false),
+				Description:  "The protocol of the application. One of http, https, rdp, ssh, or tcp.",
+			},
+			"gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the ZTNA gateway that reaches the application.",
+			},
+			"user_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the user groups assigned to the application.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Indicates whether the application is published.",
+			},
+			"external_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL users open to reach the application through ZTNA.",
+			},
+		},
+	}
+}
+
+// ztnaApplicationPayload builds the API representation of the application from the resource data
+func ztnaApplicationPayload(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"Name":            d.Get("name").(string),
+		"Description":     d.Get("description").(string),
+		"InternalAddress": d.Get("internal_address").(string),
+		"Port":            d.Get("port").(int),
+		"Protocol":        d.Get("protocol").(string),
+		"GatewayId":       d.Get("gateway_id").(string),
+		"UserGroupIds":    expandStringList(d.Get("user_group_ids").(*schema.Set).List()),
+		"Enabled":         d.Get("enabled").(bool),
+	}
+}
+
+func resourceZtnaApplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/ztna/applications", ztnaApplicationPayload(d))
+	if err != nil {
+		return apiErrorDiagnostics(err, "name")
+	}
+
+	var application struct {
+		Id string `json:"Id"`
+	}
+	if err := json.Unmarshal(responseBody, &application); err != nil {
+		return diag.FromErr(err)
+	}
+	if application.Id == "" {
+		return diag.Errorf("the API did not return an ID for ZTNA application %s", d.Get("name").(string))
+	}
+
+	d.SetId(application.Id)
+
+	return resourceZtnaApplicationRead(ctx, d, m)
+}
+
+func resourceZtnaApplicationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/ztna/applications/"+d.Id(), nil)
+	if err != nil {
+		if config.IsNotFoundError(err) {
+			return removeFromState(d, "portnox_ztna_application", "ZTNA application not found")
+		}
+		return apiErrorDiagnostics(err, "")
+	}
+
+	var application struct {
+		Name            string   `json:"Name"`
+		Description     string   `json:"Description"`
+		InternalAddress string   `json:"InternalAddress"`
+		Port            int      `json:"Port"`
+		Protocol        string   `json:"Protocol"`
+		GatewayId       string   `json:"GatewayId"`
+		UserGroupIds    []string `json:"UserGroupIds"`
+		Enabled         bool     `json:"Enabled"`
+		ExternalUrl     string   `json:"ExternalUrl"`
+	}
+	if err := json.Unmarshal(responseBody, &application); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", application.Name)
+	d.Set("description", application.Description)
+	d.Set("internal_address", application.InternalAddress)
+	d.Set("port", application.Port)
+	d.Set("protocol", application.Protocol)
+	d.Set("gateway_id", application.GatewayId)
+	d.Set("user_group_ids", application.UserGroupIds)
+	d.Set("enabled", application.Enabled)
+	d.Set("external_url", application.ExternalUrl)
+
+	return nil
+}
+
+func resourceZtnaApplicationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "PUT", "/api/ztna/applications/"+d.Id(), ztnaApplicationPayload(d)); err != nil {
+		return apiErrorDiagnostics(err, "")
+	}
+
+	return resourceZtnaApplicationRead(ctx, d, m)
+}
+
+func resourceZtnaApplicationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*common.Config)
+
+	if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/ztna/applications/"+d.Id(), nil); err != nil {
+		if !config.IsNotFoundError(err) {
+			return apiErrorDiagnostics(err, "")
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
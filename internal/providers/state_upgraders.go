@@ -0,0 +1,78 @@
+package providers
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// renameNestedAttribute copies an attribute to its new name inside every element of a list block in a raw state,
+// keeping the existing value if the element already has the new attribute set
+func renameNestedAttribute(rawState map[string]interface{}, listAttr, oldName, newName string) {
+	entries, ok := rawState[listAttr].([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, exists := entryMap[oldName]; exists {
+			if current, hasNew := entryMap[newName]; !hasNew || current == nil || current == "" {
+				entryMap[newName] = value
+			}
+		}
+	}
+}
+
+// resourceMacAccountV0 is the schema of portnox_mac_account when mac_whitelist entries only had the mac attribute
+func resourceMacAccountV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"account_name":      {Type: schema.TypeString, Required: true, ForceNew: true},
+			"block_reason":      {Type: schema.TypeString, Computed: true},
+			"created_at":        {Type: schema.TypeString, Computed: true},
+			"description":       {Type: schema.TypeString, Computed: true},
+			"group_id":          {Type: schema.TypeString, Optional: true, ForceNew: true},
+			"identity_type":     {Type: schema.TypeInt, Computed: true},
+			"is_block_by_admin": {Type: schema.TypeBool, Computed: true},
+			"org_id":            {Type: schema.TypeString, Computed: true},
+			"mac_whitelist": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mac":         {Type: schema.TypeString, Required: true},
+						"description": {Type: schema.TypeString, Optional: true},
+						"expiration":  {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+			"vendors_whitelist": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				ForceNew: true,
+			},
+			"put_devices_into_voice_vlan": {Type: schema.TypeBool, Optional: true, ForceNew: true},
+			"identity_pre_shared_key":     {Type: schema.TypeString, Optional: true, ForceNew: true},
+		},
+	}
+}
+
+// resourceMacAccountStateUpgradeV0 copies mac_whitelist.mac into the new mac_address attribute
+func resourceMacAccountStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	renameNestedAttribute(rawState, "mac_whitelist", "mac", "mac_address")
+
+	log.Printf("[DEBUG] Upgraded portnox_mac_account state from version 0: mac_whitelist.mac copied to mac_address")
+
+	return rawState, nil
+}
@@ -0,0 +1,34 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// StatisticsResource wraps the operations of a resource or data source so the API calls they make are counted under
+// its type in the API call statistics, which are reported after each operation when the provider collects them
+func StatisticsResource(resourceType string, r *schema.Resource) *schema.Resource {
+	wrap := func(operation schema.CreateContextFunc, name string) schema.CreateContextFunc {
+		if operation == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			diags := operation(common.WithStatisticsResource(ctx, resourceType), d, m)
+			if config, ok := m.(*common.Config); ok {
+				config.ReportStatistics(resourceType + " " + name)
+			}
+			return diags
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext, "create")
+	r.ReadContext = schema.ReadContextFunc(wrap(schema.CreateContextFunc(r.ReadContext), "read"))
+	r.UpdateContext = schema.UpdateContextFunc(wrap(schema.CreateContextFunc(r.UpdateContext), "update"))
+	r.DeleteContext = schema.DeleteContextFunc(wrap(schema.CreateContextFunc(r.DeleteContext), "delete"))
+
+	return r
+}
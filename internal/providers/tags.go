@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tagsSchema is the tags attribute shared by resources that support Portnox labels
+func tagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "A map of tags to assign to the object. Tags with the same key as a provider default_tags entry override it.",
+	}
+}
+
+// tagsAllSchema is the computed attribute holding the resource tags merged with the provider default_tags
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "All tags assigned to the object, including those inherited from the provider default_tags.",
+	}
+}
+
+// mergedTags returns the configured tags merged over the provider default_tags
+func mergedTags(config *common.Config, tags map[string]interface{}) map[string]string {
+	merged := make(map[string]string, len(config.DefaultTags)+len(tags))
+	for key, value := range config.DefaultTags {
+		merged[key] = value
+	}
+	for key, value := range tags {
+		merged[key] = value.(string)
+	}
+	return merged
+}
+
+// setTagsFromAPI sets tags_all to the tags returned by the API and tags to the subset not inherited unchanged
+// from the provider default_tags, so default tags do not show up as drift on the resource
+func setTagsFromAPI(config *common.Config, d *schema.ResourceData, apiTags map[string]string) {
+	configured := d.Get("tags").(map[string]interface{})
+
+	// Keep the configured tags when the response does not include tags at all
+	if apiTags == nil {
+		d.Set("tags_all", mergedTags(config, configured))
+		return
+	}
+
+	tags := make(map[string]string)
+	for key, value := range apiTags {
+		if defaultValue, isDefault := config.DefaultTags[key]; isDefault && defaultValue == value {
+			if _, isConfigured := configured[key]; !isConfigured {
+				continue
+			}
+		}
+		tags[key] = value
+	}
+
+	d.Set("tags", tags)
+	d.Set("tags_all", apiTags)
+}
+
+// customizeDiffTagsAll plans tags_all from the configured tags and the provider default_tags
+func customizeDiffTagsAll(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := m.(*common.Config)
+
+	merged := mergedTags(config, d.Get("tags").(map[string]interface{}))
+	current := d.Get("tags_all").(map[string]interface{})
+
+	changed := len(merged) != len(current)
+	for key, value := range merged {
+		if current[key] != value {
+			changed = true
+			break
+		}
+	}
+
+	if changed {
+		return d.SetNew("tags_all", merged)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package providers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TraceResource wraps the operations of a resource or data source in a trace span, the parent of the spans of the
+// API requests they make, so a long apply can be profiled by resource. Spans are only exported when tracing is
+// enabled through the OpenTelemetry environment variables.
+func TraceResource(resourceType string, r *schema.Resource) *schema.Resource {
+	wrap := func(operation schema.CreateContextFunc, name string) schema.CreateContextFunc {
+		if operation == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			ctx, span := common.StartSpan(ctx, resourceType+" "+name,
+				attribute.String("terraform.resource_type", resourceType),
+				attribute.String("terraform.operation", name),
+			)
+			diags := operation(ctx, d, m)
+
+			// The ID is only known after a create
+			span.SetAttributes(attribute.String("terraform.resource_id", d.Id()))
+			var err error
+			for _, diagnostic := range diags {
+				if diagnostic.Severity == diag.Error {
+					err = errors.New(diagnostic.Summary)
+					break
+				}
+			}
+			common.EndSpan(span, err)
+			common.FlushTraces(ctx)
+
+			return diags
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext, "create")
+	r.ReadContext = schema.ReadContextFunc(wrap(schema.CreateContextFunc(r.ReadContext), "read"))
+	r.UpdateContext = schema.UpdateContextFunc(wrap(schema.CreateContextFunc(r.UpdateContext), "update"))
+	r.DeleteContext = schema.DeleteContextFunc(wrap(schema.CreateContextFunc(r.DeleteContext), "delete"))
+
+	return r
+}
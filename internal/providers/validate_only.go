@@ -0,0 +1,55 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateOnlySummary is the summary of the warning reported when validate_only stops a mutation the API accepted
+const validateOnlySummary = "Change validated but not applied"
+
+// validateOnlyID is the ID recorded for a resource whose create was only validated. The next read removes it from
+// state, so the create is planned again.
+const validateOnlyID = "validate-only"
+
+// ValidateOnlyResource wraps a resource so that, when the provider runs with validate_only, a mutation the API
+// accepted ends the operation with a warning and the state records the planned result, as if it had been applied.
+// Updates and deletes need no wrapping for that: the update keeps the planned values and the delete removes the
+// resource. A create that never got an ID from the API is recorded with validateOnlyID, which the read drops.
+func ValidateOnlyResource(r *schema.Resource) *schema.Resource {
+	if create := r.CreateContext; create != nil {
+		r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			diags := create(ctx, d, m)
+			if m.(*common.Config).ValidateOnly && !diags.HasError() && validatedOnly(diags) && d.Id() == "" {
+				d.SetId(validateOnlyID)
+			}
+			return diags
+		}
+	}
+
+	if read := r.ReadContext; read != nil {
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if d.Id() == validateOnlyID {
+				d.SetId("")
+				return nil
+			}
+			return read(ctx, d, m)
+		}
+	}
+
+	return r
+}
+
+// validatedOnly reports whether the diagnostics of an operation include a mutation stopped by validate_only
+func validatedOnly(diags diag.Diagnostics) bool {
+	for _, d := range diags {
+		if d.Severity == diag.Warning && d.Summary == validateOnlySummary {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// offlineVendorNames are well-known vendor catalog names used to catch typos when the catalog cannot be read
+var offlineVendorNames = []string{
+	"Apple",
+	"Aruba Networks",
+	"Avaya",
+	"Axis Communications",
+	"Brother Industries",
+	"Canon",
+	"Cisco Systems",
+	"Dell",
+	"Google",
+	"Hewlett Packard",
+	"Hewlett Packard Enterprise",
+	"Hikvision",
+	"Honeywell",
+	"Intel",
+	"Juniper Networks",
+	"Lenovo",
+	"Lexmark",
+	"Microsoft",
+	"Mitel",
+	"Polycom",
+	"Raspberry Pi Foundation",
+	"Ricoh",
+	"Samsung Electronics",
+	"Ubiquiti",
+	"Xerox",
+	"Yealink",
+	"Zebra Technologies",
+}
+
+// levenshtein returns the edit distance between two strings, ignoring case
+func levenshtein(a, b string) int {
+	ra, rb := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
+
+// closestVendorName returns the candidate closest to name and its edit distance. The name is also compared to the
+// first word of each candidate, so "Ciscco" suggests "Cisco Systems".
+func closestVendorName(name string, candidates []string) (string, int) {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := levenshtein(name, candidate)
+		if fields := strings.Fields(candidate); len(fields) > 1 {
+			distance = min(distance, levenshtein(name, fields[0]))
+		}
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best, bestDistance
+}
+
+// customizeDiffVendorsWhitelist fails the plan for vendors_whitelist names that are not in the Portnox vendor
+// catalog. When the catalog cannot be read, only names close to a well-known vendor are rejected as typos.
+func customizeDiffVendorsWhitelist(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("vendors_whitelist") || !d.NewValueKnown("vendors_whitelist") {
+		return nil
+	}
+	config := m.(*common.Config)
+
+	candidates := offlineVendorNames
+	offline := false
+	catalog, err := searchVendors(ctx, config, "")
+	if err != nil || len(catalog) == 0 {
+		log.Printf("[WARN] Unable to read the Portnox vendor catalog, validating vendors_whitelist against well-known vendors only: %v", err)
+		offline = true
+	} else {
+		candidates = make([]string, 0, len(catalog))
+		for _, vendor := range catalog {
+			candidates = append(candidates, vendor.VendorName)
+		}
+	}
+
+	for _, raw := range d.Get("vendors_whitelist").([]interface{}) {
+		name, _ := raw.(string)
+
+		known := false
+		for _, candidate := range candidates {
+			if strings.EqualFold(name, candidate) {
+				known = true
+				break
+			}
+		}
+		if known {
+			continue
+		}
+
+		// A short name matching the first word of a well-known vendor may still be valid when offline
+		suggestion, distance := closestVendorName(name, candidates)
+		switch {
+		case distance >= 0 && distance <= max(2, len(name)/4) && !(offline && distance == 0):
+			return fmt.Errorf("vendors_whitelist: unknown vendor %q, did you mean %q?", name, suggestion)
+		case offline:
+			log.Printf("[WARN] vendors_whitelist: vendor %q could not be verified against the Portnox vendor catalog", name)
+		default:
+			return fmt.Errorf("vendors_whitelist: unknown vendor %q, use the portnox_vendors data source to look up vendor names", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// findMacConflicts searches for the given MAC addresses in the whitelists of accounts other than accountName,
+// and returns the ones found mapped to the name of the account that whitelists them
+func findMacConflicts(ctx context.Context, config *common.Config, accountName string, macs []string) (map[string]string, error) {
+	requested := make(map[string]string, len(macs))
+	search := make([]map[string]interface{}, 0, len(macs))
+	for _, mac := range macs {
+		if hex, ok := macHex(mac); ok {
+			requested[hex] = mac
+		}
+		search = append(search, map[string]interface{}{"Mac": mac})
+	}
+
+	payload := map[string]interface{}{
+		"MacWhiteList": search,
+	}
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts/search", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var response common.MacAccountSearchResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, err
+	}
+
+	conflicts := make(map[string]string)
+	for _, account := range response.Accounts {
+		otherAccount := account.AccountName
+		if strings.EqualFold(otherAccount, accountName) {
+			continue
+		}
+		for _, macMap := range accountMacWhiteList(config, account) {
+			whitelisted, _ := macMap["Mac"].(string)
+			hex, ok := macHex(whitelisted)
+			if !ok {
+				continue
+			}
+			if mac, found := requested[hex]; found {
+				conflicts[mac] = otherAccount
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// customizeDiffMacConflicts checks the MAC addresses being added against the whitelists of other accounts when
+// conflict_check is set, since the API only rejects them mid-apply with a cryptic error. With warn the conflicts
+// are shown in the plan as conflicting_macs, and with error they fail the plan.
+func customizeDiffMacConflicts(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	mode := d.Get("conflict_check").(string)
+	if mode == "off" || !d.NewValueKnown("account_name") || !d.NewValueKnown("mac_addresses") || !d.NewValueKnown("mac_addresses_csv") {
+		return nil
+	}
+	config := m.(*common.Config)
+	accountName := d.Get("account_name").(string)
+
+	configured := d.Get("mac_addresses").([]interface{})
+	if csvContent := d.Get("mac_addresses_csv").(string); csvContent != "" {
+		parsed, err := parseMacAddressesCSV(csvContent)
+		if err != nil {
+			return err
+		}
+		configured = parsed
+	}
+
+	// Only MAC addresses that are not in the whitelist yet can conflict
+	existing := make(map[string]bool)
+	if d.Id() != "" && !d.HasChange("account_name") {
+		oldMacs, _ := d.GetChange("mac_addresses")
+		for _, mac := range oldMacs.([]interface{}) {
+			existing[mac.(map[string]interface{})["mac_address"].(string)] = true
+		}
+	}
+	added := make([]string, 0)
+	for _, mac := range configured {
+		if macAddress := mac.(map[string]interface{})["mac_address"].(string); !existing[macAddress] {
+			added = append(added, macAddress)
+		}
+	}
+
+	conflicts := map[string]string{}
+	if len(added) > 0 {
+		found, err := findMacConflicts(ctx, config, accountName, added)
+		if err != nil {
+			// The check is advisory, so an unavailable search endpoint does not block the plan
+			log.Printf("[WARN] Unable to check MAC addresses of account %s for conflicts with other accounts: %v", accountName, err)
+			return nil
+		}
+		conflicts = found
+	}
+
+	if len(conflicts) > 0 {
+		descriptions := make([]string, 0, len(conflicts))
+		for mac, otherAccount := range conflicts {
+			descriptions = append(descriptions, fmt.Sprintf("%s (whitelisted in account %s)", mac, otherAccount))
+		}
+		sort.Strings(descriptions)
+
+		if mode == "error" {
+			return fmt.Errorf("MAC addresses already whitelisted in another account: %s", strings.Join(descriptions, ", "))
+		}
+		log.Printf("[WARN] Account %s: MAC addresses already whitelisted in another account: %s", accountName, strings.Join(descriptions, ", "))
+	}
+
+	if current := d.Get("conflicting_macs").(map[string]interface{}); len(current) != len(conflicts) || !sameConflicts(current, conflicts) {
+		return d.SetNew("conflicting_macs", conflicts)
+	}
+	return nil
+}
+
+// sameConflicts reports whether the conflicting_macs value in state matches the conflicts found
+func sameConflicts(current map[string]interface{}, conflicts map[string]string) bool {
+	for mac, account := range conflicts {
+		if current[mac] != account {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The owner and ticket of a whitelist entry are stored in its API description as space-separated tokens after the
+// free-text description, e.g. "lobby-kiosk owner.j.smith ticket.CHG-1187"
+const (
+	whitelistOwnerPrefix  = "owner."
+	whitelistTicketPrefix = "ticket."
+)
+
+// maxWhitelistDescriptionLength is the longest description the API accepts for a whitelist entry
+const maxWhitelistDescriptionLength = 64
+
+// whitelistMetadataPattern matches the characters accepted in the owner and ticket of a whitelist entry
+var whitelistMetadataPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]*$`)
+
+// encodeWhitelistDescription combines the description, owner, and ticket of a whitelist entry into the description
+// sent to the API. Without an owner or ticket, the description is sent unchanged.
+func encodeWhitelistDescription(description, owner, ticket string) string {
+	tokens := make([]string, 0, 3)
+	if description != "" {
+		tokens = append(tokens, description)
+	}
+	if owner != "" {
+		tokens = append(tokens, whitelistOwnerPrefix+owner)
+	}
+	if ticket != "" {
+		tokens = append(tokens, whitelistTicketPrefix+ticket)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// decodeWhitelistDescription splits a description returned by the API into the description, owner, and ticket
+// encoded by encodeWhitelistDescription. Descriptions without owner or ticket tokens are returned unchanged.
+func decodeWhitelistDescription(value string) (description, owner, ticket string) {
+	tokens := strings.Split(value, " ")
+	if last := tokens[len(tokens)-1]; len(last) > len(whitelistTicketPrefix) && strings.HasPrefix(last, whitelistTicketPrefix) {
+		ticket = strings.TrimPrefix(last, whitelistTicketPrefix)
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) > 0 {
+		if last := tokens[len(tokens)-1]; len(last) > len(whitelistOwnerPrefix) && strings.HasPrefix(last, whitelistOwnerPrefix) {
+			owner = strings.TrimPrefix(last, whitelistOwnerPrefix)
+			tokens = tokens[:len(tokens)-1]
+		}
+	}
+	return strings.Join(tokens, " "), owner, ticket
+}
+
+// whitelistEntryDescription returns the API description of a mac_addresses entry, encoding its owner and ticket
+func whitelistEntryDescription(macMap map[string]interface{}) string {
+	description, _ := macMap["description"].(string)
+	owner, _ := macMap["owner"].(string)
+	ticket, _ := macMap["ticket"].(string)
+	return encodeWhitelistDescription(description, owner, ticket)
+}
+
+// setWhitelistEntryDescription decodes the description of an API whitelist item into the description, owner, and
+// ticket of a mac_addresses entry
+func setWhitelistEntryDescription(entry map[string]interface{}, item map[string]interface{}) {
+	value, _ := item["Description"].(string)
+	entry["description"], entry["owner"], entry["ticket"] = decodeWhitelistDescription(value)
+}
+
+// checkWhitelistEntryDescription reports an error when the encoded description of a whitelist entry is longer than
+// the API accepts
+func checkWhitelistEntryDescription(macMap map[string]interface{}) error {
+	if description := whitelistEntryDescription(macMap); len(description) > maxWhitelistDescriptionLength {
+		return fmt.Errorf("the description, owner, and ticket of %s are stored together as %q, which is longer than %d characters", macMap["mac_address"], description, maxWhitelistDescriptionLength)
+	}
+	return nil
+}
+
+// customizeDiffMacDescriptions fails the plan when the combined description, owner, and ticket of a mac_addresses
+// entry would be longer than the API accepts
+func customizeDiffMacDescriptions(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, mac := range d.Get("mac_addresses").([]interface{}) {
+		macMap, ok := mac.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := checkWhitelistEntryDescription(macMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// parseDurationWithDays parses a Go duration, additionally accepting a whole number of days such as 90d
+func parseDurationWithDays(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("invalid number of days %q", value)
+		}
+		return time.Duration(count) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func validateDurationWithDays(v interface{}, k string) ([]string, []error) {
+	duration, err := parseDurationWithDays(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a duration such as 90d or 2160h: %s", k, err)}
+	}
+	if duration <= 0 {
+		return nil, []error{fmt.Errorf("%s must be a positive duration", k)}
+	}
+	return nil, nil
+}
+
+// staleMacAddresses returns the sorted MAC addresses of the entries whose device has not connected within the
+// window. Devices that never connected are stale once they were added longer ago than the window; entries
+// without usable timestamps are never stale.
+func staleMacAddresses(entries []map[string]interface{}, window time.Duration, now time.Time) []string {
+	stale := make([]string, 0)
+	cutoff := now.Add(-window)
+
+	for _, entry := range entries {
+		lastActivity, _ := entry["last_seen"].(string)
+		if lastActivity == "" {
+			lastActivity, _ = entry["created_at"].(string)
+		}
+		activity, err := time.Parse(time.RFC3339, lastActivity)
+		if err != nil {
+			continue
+		}
+		if activity.Before(cutoff) {
+			stale = append(stale, entry["mac_address"].(string))
+		}
+	}
+
+	sort.Strings(stale)
+	return stale
+}
+
+// customizeDiffPruneMacs schedules an update when stale MACs are waiting to be pruned, or when pruning was turned
+// off and previously pruned MACs must be restored
+func customizeDiffPruneMacs(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	prune := d.Get("prune").(bool)
+	staleMacs := d.Get("stale_macs").([]interface{})
+	prunedMacs := d.Get("pruned_macs").([]interface{})
+
+	if (prune && len(staleMacs) > 0) || (!prune && len(prunedMacs) > 0) {
+		return d.SetNewComputed("pruned_macs")
+	}
+	return nil
+}
+
+// stringSet returns the string elements of a list attribute as a set
+func stringSet(values []interface{}) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			set[s] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,23 @@
+package providers
+
+// acceptedWhitelistEntries returns the whitelist-add entries the API did not report as rejected
+func acceptedWhitelistEntries(entries []map[string]interface{}, failures map[string]string) []map[string]interface{} {
+	accepted := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		if _, failed := failures[entry["Mac"].(string)]; !failed {
+			accepted = append(accepted, entry)
+		}
+	}
+	return accepted
+}
+
+// withoutMacs returns the mac_addresses entries whose MAC address is not a key of excluded
+func withoutMacs(macAddresses []interface{}, excluded map[string]bool) []interface{} {
+	kept := make([]interface{}, 0, len(macAddresses))
+	for _, mac := range macAddresses {
+		if !excluded[mac.(map[string]interface{})["mac_address"].(string)] {
+			kept = append(kept, mac)
+		}
+	}
+	return kept
+}
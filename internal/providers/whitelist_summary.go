@@ -0,0 +1,76 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// expiringSoonWindow is how far ahead expiring_within_30d_count looks for expirations
+const expiringSoonWindow = 30 * 24 * time.Hour
+
+// whitelistExpirationCounts counts the entries that have expired and the ones that expire within the window.
+// Entries without an expiration, or with one that cannot be parsed as RFC 3339, never expire.
+func whitelistExpirationCounts(entries []interface{}, window time.Duration, now time.Time) (expiring, expired int) {
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, _ := entryMap["expiration"].(string)
+		expiration, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			continue
+		}
+		switch {
+		case !expiration.After(now):
+			expired++
+		case expiration.Before(now.Add(window)):
+			expiring++
+		}
+	}
+	return expiring, expired
+}
+
+// setWhitelistSummary sets the computed summary attributes from the MAC addresses managed by the resource
+func setWhitelistSummary(d *schema.ResourceData, entries []interface{}) {
+	expiring, expired := whitelistExpirationCounts(entries, expiringSoonWindow, time.Now())
+
+	d.Set("mac_count", len(entries))
+	d.Set("total_count", len(entries))
+	d.Set("expiring_within_30d_count", expiring)
+	d.Set("expired_count", expired)
+}
+
+// expiredEntriesWarning warns about the entries returned by the API whose expiration has passed. Portnox is expected
+// to stop authorizing a device once its entry expires, so an expired entry that is still in the whitelist points to
+// an enforcement gap the operator should know about at plan time.
+func expiredEntriesWarning(accountName string, entries []interface{}, now time.Time) diag.Diagnostics {
+	expired := make([]string, 0)
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, _ := entryMap["expiration"].(string)
+		if expiration, err := time.Parse(time.RFC3339, value); err == nil && !expiration.After(now) {
+			mac, _ := entryMap["mac_address"].(string)
+			expired = append(expired, fmt.Sprintf("%s (expired %s)", mac, value))
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	sort.Strings(expired)
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Expired whitelist entries still present",
+		Detail: fmt.Sprintf("Account %s still returns %d whitelist entries whose expiration has passed: %s. "+
+			"Their devices may still be authorized; remove the entries or extend their expiration.", accountName, len(expired), strings.Join(expired, ", ")),
+	}}
+}
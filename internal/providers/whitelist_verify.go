@@ -0,0 +1,127 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+)
+
+// verifyWriteAttempts is how many times the whitelist is re-read and the missing changes re-sent when verify_writes is set
+const verifyWriteAttempts = 3
+
+// whitelistMacs returns the hex digits of the MAC addresses currently in the whitelist of an account. It reads the
+// account with MakeRequestWithRetry rather than MakeCachedRequestWithRetry, so a response cached before the write is
+// never returned.
+func whitelistMacs(ctx context.Context, config *common.Config, accountName string) (map[string]bool, error) {
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", "/api/mac-based-accounts/"+accountName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account common.MacAccount
+	if err := json.Unmarshal(responseBody, &account); err != nil {
+		return nil, err
+	}
+
+	macs := make(map[string]bool)
+	for _, macMap := range accountMacWhiteList(config, account) {
+		mac, _ := macMap["Mac"].(string)
+		if digits, ok := macHex(mac); ok {
+			macs[digits] = true
+		}
+	}
+	return macs, nil
+}
+
+// verifyWhitelistWrites re-reads the whitelist of an account after entries were added and MAC addresses removed,
+// and re-sends the changes the API did not apply, as it has been seen to accept a batch but drop part of it under
+// load. It does nothing unless verify_writes is set.
+func verifyWhitelistWrites(ctx context.Context, config *common.Config, accountName string, added []map[string]interface{}, removed []string) error {
+	if !config.VerifyWrites || (len(added) == 0 && len(removed) == 0) {
+		return nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		present, err := whitelistMacs(ctx, config, accountName)
+		if err != nil {
+			return fmt.Errorf("error verifying the whitelist of account %s: %w", accountName, err)
+		}
+
+		// The API may return another notation than the one sent, so MAC addresses are compared on their hex digits
+		missing := make([]map[string]interface{}, 0)
+		for _, entry := range added {
+			if digits, _ := macHex(entry["Mac"].(string)); !present[digits] {
+				missing = append(missing, entry)
+			}
+		}
+		lingering := make([]map[string]interface{}, 0)
+		for _, mac := range removed {
+			if digits, _ := macHex(mac); present[digits] {
+				lingering = append(lingering, map[string]interface{}{"Mac": mac})
+			}
+		}
+
+		if len(missing) == 0 && len(lingering) == 0 {
+			return nil
+		}
+		if attempt > verifyWriteAttempts {
+			return fmt.Errorf("the whitelist of account %s did not converge after %d attempts: %s", accountName, verifyWriteAttempts, unconvergedMacs(missing, lingering))
+		}
+
+		log.Printf("[WARN] Whitelist of account %s is missing changes after a write, re-sending (attempt %d/%d): %s", accountName, attempt, verifyWriteAttempts, unconvergedMacs(missing, lingering))
+
+		if len(lingering) > 0 {
+			payload := map[string]interface{}{
+				"AccountName":  accountName,
+				"MacWhiteList": lingering,
+			}
+			if _, err := config.MakeRequestWithRetry(ctx, "DELETE", "/api/mac-based-accounts/mac-whitelist-remove", payload); err != nil {
+				return err
+			}
+		}
+		if len(missing) > 0 {
+			payload := map[string]interface{}{
+				"AccountName":  accountName,
+				"MacWhiteList": missing,
+			}
+			if _, err := config.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts/mac-whitelist-add", payload); err != nil {
+				return err
+			}
+		}
+
+		// Give the API time to apply the changes before reading the whitelist again
+		timer := time.NewTimer(time.Duration(attempt) * time.Second)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// unconvergedMacs describes the MAC addresses still missing from or lingering in a whitelist
+func unconvergedMacs(missing, lingering []map[string]interface{}) string {
+	parts := make([]string, 0, 2)
+	for _, group := range []struct {
+		label   string
+		entries []map[string]interface{}
+	}{{"not added", missing}, {"not removed", lingering}} {
+		if len(group.entries) == 0 {
+			continue
+		}
+		macs := make([]string, 0, len(group.entries))
+		for _, entry := range group.entries {
+			macs = append(macs, entry["Mac"].(string))
+		}
+		sort.Strings(macs)
+		parts = append(parts, group.label+" "+strings.Join(macs, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,59 @@
+package providers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// attributePath converts a flatmap key such as smtp.0.password_wo into the path of the attribute in the raw configuration
+func attributePath(key string) cty.Path {
+	var path cty.Path
+	for _, step := range strings.Split(key, ".") {
+		if index, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(index)
+			continue
+		}
+		path = path.GetAttr(step)
+	}
+	return path
+}
+
+// writeOnlyString returns the configured value of a write-only argument, or an empty string when it is unset.
+// Write-only arguments are never persisted to the plan or state, so they are only available from the raw configuration.
+func writeOnlyString(d *schema.ResourceData, key string) (string, diag.Diagnostics) {
+	value, diags := d.GetRawConfigAt(attributePath(key))
+	if diags.HasError() {
+		return "", diags
+	}
+	if value.IsNull() || !value.IsKnown() || !value.Type().Equals(cty.String) {
+		return "", nil
+	}
+	return value.AsString(), nil
+}
+
+// changedSecret returns the secret to send to the API and whether it needs to be sent. A secret can be configured
+// either as a regular sensitive attribute, sent when it is set or changed, or as a write-only argument, sent on
+// create and whenever its version attribute changes, because Terraform cannot detect changes to write-only values.
+func changedSecret(d *schema.ResourceData, attribute, writeOnly, version string) (string, bool, diag.Diagnostics) {
+	// Moving a secret from the attribute to the write-only argument clears the attribute, which must not clear the secret
+	sendWriteOnly := d.IsNewResource() || d.HasChange(version) || (d.HasChange(attribute) && d.Get(attribute).(string) == "")
+	if sendWriteOnly {
+		value, diags := writeOnlyString(d, writeOnly)
+		if diags.HasError() {
+			return "", false, diags
+		}
+		if value != "" {
+			return value, true, nil
+		}
+	}
+
+	if d.IsNewResource() || d.HasChange(attribute) {
+		return d.Get(attribute).(string), true, nil
+	}
+
+	return "", false, nil
+}
@@ -0,0 +1,167 @@
+// Package sweep registers sweepers that delete objects left behind in the test tenant by failed acceptance test
+// runs, so later runs do not fail with name conflicts. The TestMain of the package runs them with
+// go test ./internal/sweep -sweep=<tenant>.
+//
+// Only objects whose name starts with the test prefix are deleted. The prefix defaults to tf-acc-test and can be
+// changed with PORTNOX_SWEEP_PREFIX.
+package sweep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// defaultPrefix is the name prefix of the objects created by acceptance tests
+const defaultPrefix = "tf-acc-test"
+
+// defaultBaseURL is the Portnox API used when PORTNOX_BASE_URL is not set, matching the provider base_url default
+const defaultBaseURL = "https://clear.portnox.com:8081/CloudPortalBackEnd"
+
+func init() {
+	resource.AddTestSweepers("portnox_mac_account", &resource.Sweeper{
+		Name: "portnox_mac_account",
+		F:    sweepMacAccounts,
+	})
+	resource.AddTestSweepers("portnox_user_group", &resource.Sweeper{
+		Name: "portnox_user_group",
+		F:    sweepUserGroups,
+		// Accounts and users may reference the groups, so they are removed first
+		Dependencies: []string{"portnox_mac_account", "portnox_local_user"},
+	})
+	resource.AddTestSweepers("portnox_local_user", &resource.Sweeper{
+		Name: "portnox_local_user",
+		F:    sweepLocalUsers,
+	})
+}
+
+// prefix returns the name prefix of the objects to sweep
+func prefix() string {
+	if value := os.Getenv("PORTNOX_SWEEP_PREFIX"); value != "" {
+		return value
+	}
+	return defaultPrefix
+}
+
+// sweeperConfig builds an API client for the test tenant from the same environment variables as the provider
+func sweeperConfig() (*common.Config, error) {
+	apiKey := os.Getenv("TF_VAR_PORTNOX_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("TF_VAR_PORTNOX_API_KEY must be set to run sweepers")
+	}
+
+	baseURL := os.Getenv("PORTNOX_BASE_URL")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	return &common.Config{
+		APIKey:        apiKey,
+		BaseURL:       baseURL,
+		Retries:       3,
+		RetryInterval: 1,
+		UserAgent:     "terraform-provider-portnox/sweeper",
+	}, nil
+}
+
+// deleteAll deletes every object path, continuing past failures so one stuck object does not block the rest
+func deleteAll(ctx context.Context, config *common.Config, kind string, paths map[string]string) error {
+	var failed []string
+	for name, path := range paths {
+		log.Printf("[INFO] Sweeping %s %s", kind, name)
+		if _, err := config.MakeRequestWithRetry(ctx, "DELETE", path, nil); err != nil && !config.IsNotFoundError(err) {
+			log.Printf("[ERROR] Error sweeping %s %s: %s", kind, name, err)
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to sweep %d %s(s): %s", len(failed), kind, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func sweepMacAccounts(tenant string) error {
+	config, err := sweeperConfig()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "POST", "/api/mac-based-accounts/search", map[string]interface{}{
+		"AccountName": prefix(),
+	})
+	if err != nil {
+		return fmt.Errorf("error listing MAC accounts: %s", err)
+	}
+
+	var response struct {
+		Accounts []struct {
+			AccountName string `json:"AccountName"`
+		} `json:"Accounts"`
+	}
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return fmt.Errorf("error parsing MAC accounts: %s", err)
+	}
+
+	paths := make(map[string]string)
+	for _, account := range response.Accounts {
+		if strings.HasPrefix(account.AccountName, prefix()) {
+			paths[account.AccountName] = "/api/mac-based-accounts/" + account.AccountName
+		}
+	}
+
+	return deleteAll(ctx, config, "MAC account", paths)
+}
+
+func sweepUserGroups(tenant string) error {
+	return sweepNamedObjects("user group", "/api/user-groups")
+}
+
+func sweepLocalUsers(tenant string) error {
+	return sweepNamedObjects("local user", "/api/users")
+}
+
+// sweepNamedObjects deletes the test objects of a collection endpoint that lists objects with an Id and a Name, or
+// a Username for users
+func sweepNamedObjects(kind, endpoint string) error {
+	config, err := sweeperConfig()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	responseBody, err := config.MakeRequestWithRetry(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("error listing %ss: %s", kind, err)
+	}
+
+	var objects []struct {
+		Id       string `json:"Id"`
+		Name     string `json:"Name"`
+		Username string `json:"Username"`
+	}
+	if err := json.Unmarshal(responseBody, &objects); err != nil {
+		return fmt.Errorf("error parsing %ss: %s", kind, err)
+	}
+
+	paths := make(map[string]string)
+	for _, object := range objects {
+		name := object.Name
+		if name == "" {
+			name = object.Username
+		}
+		if object.Id != "" && strings.HasPrefix(name, prefix()) {
+			paths[name] = endpoint + "/" + object.Id
+		}
+	}
+
+	return deleteAll(ctx, config, kind, paths)
+}
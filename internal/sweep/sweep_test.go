@@ -0,0 +1,12 @@
+package sweep
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestMain runs the sweepers when go test is called with -sweep, and the tests of the package otherwise
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
@@ -0,0 +1,58 @@
+// Package whitelistdiff computes the changes between the current and desired MAC whitelist entries of an account,
+// so the resources that manage whitelists send the same requests for the same change.
+//
+// Entries are keyed by MAC address and hold the attributes of a mac_addresses block: mac_address, description,
+// expiration and, where the resource supports them, vlan and voice.
+package whitelistdiff
+
+import "sort"
+
+// ComputeDelta returns the entries of desired that are not in current, the entries of current that are not in
+// desired, and the entries of desired whose attributes differ from current. Each list is sorted by MAC address.
+func ComputeDelta(current, desired map[string]map[string]interface{}) (adds, removes, updates []map[string]interface{}) {
+	adds = make([]map[string]interface{}, 0)
+	removes = make([]map[string]interface{}, 0)
+	updates = make([]map[string]interface{}, 0)
+
+	for _, mac := range sortedMacs(desired) {
+		currentEntry, exists := current[mac]
+		switch {
+		case !exists:
+			adds = append(adds, desired[mac])
+		case Changed(currentEntry, desired[mac]):
+			updates = append(updates, desired[mac])
+		}
+	}
+	for _, mac := range sortedMacs(current) {
+		if _, exists := desired[mac]; !exists {
+			removes = append(removes, current[mac])
+		}
+	}
+
+	return adds, removes, updates
+}
+
+// Changed reports whether the attributes sent to the API differ between two values of an entry. Unset attributes
+// and their zero values are the same, so an entry without an expiration equals one with an empty expiration.
+func Changed(current, desired map[string]interface{}) bool {
+	for _, attribute := range []string{"description", "owner", "ticket", "expiration", "vlan"} {
+		currentValue, _ := current[attribute].(string)
+		desiredValue, _ := desired[attribute].(string)
+		if currentValue != desiredValue {
+			return true
+		}
+	}
+	currentVoice, _ := current["voice"].(bool)
+	desiredVoice, _ := desired["voice"].(bool)
+	return currentVoice != desiredVoice
+}
+
+// sortedMacs returns the MAC addresses of the entries in order
+func sortedMacs(entries map[string]map[string]interface{}) []string {
+	macs := make([]string, 0, len(entries))
+	for mac := range entries {
+		macs = append(macs, mac)
+	}
+	sort.Strings(macs)
+	return macs
+}
@@ -0,0 +1,177 @@
+package whitelistdiff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChanged(t *testing.T) {
+	cases := []struct {
+		name    string
+		current map[string]interface{}
+		desired map[string]interface{}
+		want    bool
+	}{
+		{
+			name:    "unchanged",
+			current: map[string]interface{}{"description": "printer", "expiration": "2030-01-01T00:00:00Z"},
+			desired: map[string]interface{}{"description": "printer", "expiration": "2030-01-01T00:00:00Z"},
+			want:    false,
+		},
+		{
+			name:    "description changed",
+			current: map[string]interface{}{"description": "printer"},
+			desired: map[string]interface{}{"description": "scanner"},
+			want:    true,
+		},
+		{
+			name:    "expiration added",
+			current: map[string]interface{}{"description": "printer"},
+			desired: map[string]interface{}{"description": "printer", "expiration": "2030-01-01T00:00:00Z"},
+			want:    true,
+		},
+		{
+			name:    "expiration removed",
+			current: map[string]interface{}{"expiration": "2030-01-01T00:00:00Z"},
+			desired: map[string]interface{}{"expiration": ""},
+			want:    true,
+		},
+		{
+			name:    "expiration changed",
+			current: map[string]interface{}{"expiration": "2030-01-01T00:00:00Z"},
+			desired: map[string]interface{}{"expiration": "2031-01-01T00:00:00Z"},
+			want:    true,
+		},
+		{
+			name:    "unset and empty expiration",
+			current: map[string]interface{}{"expiration": nil},
+			desired: map[string]interface{}{"expiration": ""},
+			want:    false,
+		},
+		{
+			name:    "vlan changed",
+			current: map[string]interface{}{"vlan": "10"},
+			desired: map[string]interface{}{"vlan": "20"},
+			want:    true,
+		},
+		{
+			name:    "voice changed",
+			current: map[string]interface{}{"voice": false},
+			desired: map[string]interface{}{"voice": true},
+			want:    true,
+		},
+		{
+			name:    "unset and false voice",
+			current: map[string]interface{}{},
+			desired: map[string]interface{}{"voice": false},
+			want:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Changed(tc.current, tc.desired); got != tc.want {
+				t.Errorf("Changed() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeDelta(t *testing.T) {
+	entry := func(mac, description string) map[string]interface{} {
+		return map[string]interface{}{"mac_address": mac, "description": description}
+	}
+	macs := func(entries []map[string]interface{}) []string {
+		result := make([]string, 0, len(entries))
+		for _, e := range entries {
+			result = append(result, e["mac_address"].(string))
+		}
+		return result
+	}
+
+	cases := []struct {
+		name        string
+		current     []map[string]interface{}
+		desired     []map[string]interface{}
+		wantAdds    []string
+		wantRemoves []string
+		wantUpdates []string
+	}{
+		{
+			name:        "empty",
+			wantAdds:    []string{},
+			wantRemoves: []string{},
+			wantUpdates: []string{},
+		},
+		{
+			name:        "all new",
+			desired:     []map[string]interface{}{entry("AA:BB:CC:DD:EE:02", "b"), entry("AA:BB:CC:DD:EE:01", "a")},
+			wantAdds:    []string{"AA:BB:CC:DD:EE:01", "AA:BB:CC:DD:EE:02"},
+			wantRemoves: []string{},
+			wantUpdates: []string{},
+		},
+		{
+			name:        "all removed",
+			current:     []map[string]interface{}{entry("AA:BB:CC:DD:EE:01", "a")},
+			wantAdds:    []string{},
+			wantRemoves: []string{"AA:BB:CC:DD:EE:01"},
+			wantUpdates: []string{},
+		},
+		{
+			name:        "unchanged entries are not sent",
+			current:     []map[string]interface{}{entry("AA:BB:CC:DD:EE:01", "a"), entry("AA:BB:CC:DD:EE:02", "b")},
+			desired:     []map[string]interface{}{entry("AA:BB:CC:DD:EE:01", "a"), entry("AA:BB:CC:DD:EE:02", "b")},
+			wantAdds:    []string{},
+			wantRemoves: []string{},
+			wantUpdates: []string{},
+		},
+		{
+			name:        "description change is an update, not an add",
+			current:     []map[string]interface{}{entry("AA:BB:CC:DD:EE:01", "a")},
+			desired:     []map[string]interface{}{entry("AA:BB:CC:DD:EE:01", "renamed")},
+			wantAdds:    []string{},
+			wantRemoves: []string{},
+			wantUpdates: []string{"AA:BB:CC:DD:EE:01"},
+		},
+		{
+			name: "mixed",
+			current: []map[string]interface{}{
+				entry("AA:BB:CC:DD:EE:01", "keep"),
+				entry("AA:BB:CC:DD:EE:02", "old"),
+				entry("AA:BB:CC:DD:EE:03", "gone"),
+			},
+			desired: []map[string]interface{}{
+				entry("AA:BB:CC:DD:EE:04", "new"),
+				entry("AA:BB:CC:DD:EE:02", "new"),
+				entry("AA:BB:CC:DD:EE:01", "keep"),
+			},
+			wantAdds:    []string{"AA:BB:CC:DD:EE:04"},
+			wantRemoves: []string{"AA:BB:CC:DD:EE:03"},
+			wantUpdates: []string{"AA:BB:CC:DD:EE:02"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			current := make(map[string]map[string]interface{})
+			for _, e := range tc.current {
+				current[e["mac_address"].(string)] = e
+			}
+			desired := make(map[string]map[string]interface{})
+			for _, e := range tc.desired {
+				desired[e["mac_address"].(string)] = e
+			}
+
+			adds, removes, updates := ComputeDelta(current, desired)
+			if got := macs(adds); !reflect.DeepEqual(got, tc.wantAdds) {
+				t.Errorf("adds = %v, want %v", got, tc.wantAdds)
+			}
+			if got := macs(removes); !reflect.DeepEqual(got, tc.wantRemoves) {
+				t.Errorf("removes = %v, want %v", got, tc.wantRemoves)
+			}
+			if got := macs(updates); !reflect.DeepEqual(got, tc.wantUpdates) {
+				t.Errorf("updates = %v, want %v", got, tc.wantUpdates)
+			}
+		})
+	}
+}
@@ -5,8 +5,13 @@ import (
 	"github.com/portnox-community/terraform-provider-portnox/provider"
 )
 
+// version is set by goreleaser through ldflags
+var version = "dev"
+
 func main() {
+	provider.Version = version
+
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: provider.Provider,
+		GRPCProviderFunc: provider.ProviderServer,
 	})
 }
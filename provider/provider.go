@@ -2,16 +2,24 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/portnox-community/terraform-provider-portnox/common"
 	providers "github.com/portnox-community/terraform-provider-portnox/internal/providers"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// Version is the provider release version reported in the User-Agent, set by main from the release ldflags
+var Version = "dev"
+
 // Provider returns the schema.Provider for Portnox
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
@@ -37,31 +45,306 @@ func Provider() *schema.Provider {
 				Default:     1, // Default retry interval in seconds
 				Description: "The retry interval in seconds between retries.",
 			},
+			"read_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of retries for read requests (GET and search). Defaults to retries.",
+			},
+			"read_retry_interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The retry interval in seconds for read requests. Defaults to retry_interval.",
+			},
+			"write_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of retries for requests that create, update, or delete objects. Defaults to retries.",
+			},
+			"write_retry_interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The retry interval in seconds for requests that create, update, or delete objects. Defaults to retry_interval.",
+			},
+			"max_retry_elapsed_time": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The total wall-clock time in seconds after which no API request is retried. The budget starts with the first request and is shared by all resources. Unset or 0 means no limit.",
+			},
+			"backoff_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      common.BackoffExponentialJitter,
+				ValidateFunc: validation.StringInSlice(common.BackoffStrategies, false),
+				Description:  "How the wait between retries grows: exponential, exponential_jitter, constant, or decorrelated.",
+			},
+			"max_backoff": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The longest wait in seconds between two retries. Unset or 0 means no limit.",
+			},
+			"disable_request_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disable the short-lived cache that deduplicates identical GET requests made by data sources during a single plan or apply.",
+			},
+			"disable_request_body_logging": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Omit request and response bodies from the provider debug logs. Secrets in logged bodies are always redacted.",
+			},
+			"whitelist_batch_window_ms": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "The window in milliseconds in which portnox_mac_account_address creations for the same account are coalesced into one API request. Batching is disabled by default; when the API rejects a batch without reporting per-entry results, every creation in it fails.",
+			},
+			"whitelist_chunk_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1000,
+				Description: "The maximum number of MAC addresses sent in one whitelist add or remove request. Larger changes are split into several requests.",
+			},
+			"compress_requests": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Gzip-compress request bodies of 8 KiB or more. Responses are always accepted compressed.",
+			},
+			"verify_writes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Re-read the whitelist after each whitelist write and re-send the changes the API did not apply.",
+			},
+			"validate_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send every create, update, and delete to the API for validation only, without applying it. Changes the API accepts are reported with a 'Change validated but not applied' warning, and the state records the planned result.",
+			},
+			"circuit_breaker_threshold": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     5,
+				Description: "The number of consecutive API server errors or connection failures after which the remaining requests fail fast. Set to 0 to disable the circuit breaker.",
+			},
+			"circuit_breaker_cooldown": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     30,
+				Description: "The time in seconds requests fail fast after the circuit breaker trips, before the API is probed again.",
+			},
+			"audit_log_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A file that receives a JSON Lines audit record (timestamp, method, endpoint, resource, status) for every API mutation, with secrets redacted.",
+			},
+			"api_statistics": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Log a summary of the API calls, retries, rate-limited responses, and time spent, by resource type, after every resource and data source operation.",
+			},
+			"api_statistics_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A file rewritten with a JSON summary of the API calls, retries, rate-limited responses, and time spent, by resource type, after every resource and data source operation.",
+			},
+			"partner_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An optional partner identifier appended to the User-Agent sent with every API request.",
+			},
+			"default_tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags merged into the tags of every resource that supports them, such as ownership or cost center.",
+			},
+			"endpoints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: endpointsSchema(),
+				},
+				Description: "Overrides of the API path prefixes used by the provider, for endpoints that moved in a newer API version.",
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An optional custom string appended to the User-Agent sent with every API request.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"portnox_mac_account":           providers.ResourceMacAccount(),
-			"portnox_mac_account_address":   providers.ResourceMacAccountAddress(),
-			"portnox_mac_account_addresses": providers.ResourceMacAccountAddresses(),
+			"portnox_mac_account":               providers.ResourceMacAccount(),
+			"portnox_mac_account_address":       providers.ResourceMacAccountAddress(),
+			"portnox_mac_account_addresses":     providers.ResourceMacAccountAddresses(),
+			"portnox_rest_request":              providers.ResourceRestRequest(),
+			"portnox_ssid":                      providers.ResourceSsid(),
+			"portnox_vlan":                      providers.ResourceVlan(),
+			"portnox_radsec_certificate":        providers.ResourceRadsecCertificate(),
+			"portnox_agent_configuration":       providers.ResourceAgentConfiguration(),
+			"portnox_posture_check":             providers.ResourcePostureCheck(),
+			"portnox_conditional_access_rule":   providers.ResourceConditionalAccessRule(),
+			"portnox_ztna_application":          providers.ResourceZtnaApplication(),
+			"portnox_local_user":                providers.ResourceLocalUser(),
+			"portnox_user_group":                providers.ResourceUserGroup(),
+			"portnox_coa_action":                providers.ResourceCoaAction(),
+			"portnox_mac_whitelist":             providers.ResourceMacWhitelist(),
+			"portnox_device_profiling_rule":     providers.ResourceDeviceProfilingRule(),
+			"portnox_network_segment":           providers.ResourceNetworkSegment(),
+			"portnox_ldap_integration":          providers.ResourceLdapIntegration(),
+			"portnox_scim_settings":             providers.ResourceScimSettings(),
+			"portnox_notification_settings":     providers.ResourceNotificationSettings(),
+			"portnox_branding":                  providers.ResourceBranding(),
+			"portnox_account_expiration_policy": providers.ResourceAccountExpirationPolicy(),
+			"portnox_policy_assignment":         providers.ResourcePolicyAssignment(),
+			"portnox_nas_devices":               providers.ResourceNasDevices(),
+			"portnox_nas_device":                providers.ResourceNasDevice(),
+			"portnox_site_broker":               providers.ResourceSiteBroker(),
+			"portnox_compliance_report":         providers.ResourceComplianceReport(),
+			"portnox_mab_bypass_exception":      providers.ResourceMabBypassException(),
+			"portnox_access_schedule":           providers.ResourceAccessSchedule(),
+			"portnox_account_note":              providers.ResourceAccountNote(),
+			"portnox_child_organization":        providers.ResourceChildOrganization(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"portnox_mac_account": providers.DataSourceMacAccount(),
+			"portnox_mac_account":           providers.DataSourceMacAccount(),
+			"portnox_mac_in_oui":            providers.DataSourceMacInOui(),
+			"portnox_rest_request":          providers.DataSourceRestRequest(),
+			"portnox_vlan":                  providers.DataSourceVlan(),
+			"portnox_radius_endpoints":      providers.DataSourceRadiusEndpoints(),
+			"portnox_active_sessions":       providers.DataSourceActiveSessions(),
+			"portnox_events":                providers.DataSourceEvents(),
+			"portnox_license":               providers.DataSourceLicense(),
+			"portnox_organization":          providers.DataSourceOrganization(),
+			"portnox_mac_account_addresses": providers.DataSourceMacAccountAddresses(),
+			"portnox_vendors":               providers.DataSourceVendors(),
+			"portnox_network_segment":       providers.DataSourceNetworkSegment(),
+			"portnox_expiring_macs":         providers.DataSourceExpiringMacs(),
+			"portnox_policies":              providers.DataSourcePolicies(),
+			"portnox_policy":                providers.DataSourcePolicy(),
+			"portnox_nas_devices":           providers.DataSourceNasDevices(),
+			"portnox_unmanaged_devices":     providers.DataSourceUnmanagedDevices(),
+			"portnox_risk_scores":           providers.DataSourceRiskScores(),
+			"portnox_format_macs":           providers.DataSourceFormatMacs(),
+			"portnox_whoami":                providers.DataSourceWhoami(),
 		},
-		ConfigureContextFunc: func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-			apiKey := d.Get("api_key").(string)
-			baseURL := d.Get("base_url").(string)
-			retries := d.Get("retries").(int)
-			retryInterval := d.Get("retry_interval").(int)
-
-			if apiKey == "" {
-				return nil, diag.Errorf("API key must be provided")
+	}
+
+	// Attribute API mutations to the resource making them in the audit trail and API calls in the API statistics,
+	// trace each operation, and keep the prior state when validate_only stops an update
+	for resourceType, resource := range p.ResourcesMap {
+		providers.ValidateOnlyResource(providers.AuditResource(resourceType, providers.TraceResource(resourceType, providers.StatisticsResource(resourceType, resource))))
+	}
+	for dataSourceType, dataSource := range p.DataSourcesMap {
+		providers.TraceResource("data."+dataSourceType, providers.StatisticsResource("data."+dataSourceType, dataSource))
+	}
+
+	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		if diags := validateProviderConfig(d); diags.HasError() {
+			return nil, diags
+		}
+
+		apiKey := d.Get("api_key").(string)
+		baseURL, _ := normalizeBaseURL(d.Get("base_url").(string))
+		retries := d.Get("retries").(int)
+		retryInterval := d.Get("retry_interval").(int)
+
+		// Identify the provider and Terraform versions so Portnox support and API gateways can attribute traffic
+		userAgent := fmt.Sprintf("terraform-provider-portnox/%s terraform/%s", Version, p.TerraformVersion)
+		if partnerID := d.Get("partner_id").(string); partnerID != "" {
+			userAgent += " partner/" + partnerID
+		}
+		if suffix := d.Get("user_agent_suffix").(string); suffix != "" {
+			userAgent += " " + suffix
+		}
+
+		defaultTags := make(map[string]string)
+		for key, value := range d.Get("default_tags").(map[string]interface{}) {
+			defaultTags[key] = value.(string)
+		}
+
+		auditLogPath := d.Get("audit_log_path").(string)
+		if auditLogPath != "" {
+			if err := common.CheckAuditLogPath(auditLogPath); err != nil {
+				return nil, diag.FromErr(err)
 			}
+		}
 
-			return &common.Config{
-				APIKey:        apiKey,
-				BaseURL:       baseURL,
-				Retries:       retries,
-				RetryInterval: retryInterval,
-			}, nil
-		},
+		statisticsPath := d.Get("api_statistics_path").(string)
+		if statisticsPath != "" {
+			if err := common.CheckStatisticsPath(statisticsPath); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		}
+
+		endpointOverrides, diags := expandEndpoints(d.Get("endpoints").([]interface{}))
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		config := &common.Config{
+			APIKey:                    apiKey,
+			BaseURL:                   baseURL,
+			Retries:                   retries,
+			RetryInterval:             retryInterval,
+			DisableRequestCache:       d.Get("disable_request_cache").(bool),
+			DisableRequestBodyLogging: d.Get("disable_request_body_logging").(bool),
+			AuditLogPath:              auditLogPath,
+			LogStatistics:             d.Get("api_statistics").(bool),
+			StatisticsPath:            statisticsPath,
+			WhitelistBatchWindow:      time.Duration(d.Get("whitelist_batch_window_ms").(int)) * time.Millisecond,
+			VerifyWrites:              d.Get("verify_writes").(bool),
+			ValidateOnly:              d.Get("validate_only").(bool),
+			WhitelistChunkSize:        d.Get("whitelist_chunk_size").(int),
+			CompressRequests:          d.Get("compress_requests").(bool),
+			MaxRetryElapsedTime:       time.Duration(d.Get("max_retry_elapsed_time").(int)) * time.Second,
+			BackoffStrategy:           d.Get("backoff_strategy").(string),
+			MaxBackoff:                time.Duration(d.Get("max_backoff").(int)) * time.Second,
+			ReadRetries:               d.Get("read_retries").(int),
+			ReadRetryInterval:         d.Get("read_retry_interval").(int),
+			WriteRetries:              d.Get("write_retries").(int),
+			WriteRetryInterval:        d.Get("write_retry_interval").(int),
+			CircuitBreakerThreshold:   d.Get("circuit_breaker_threshold").(int),
+			CircuitBreakerCooldown:    time.Duration(d.Get("circuit_breaker_cooldown").(int)) * time.Second,
+			UserAgent:                 userAgent,
+			DefaultTags:               defaultTags,
+			EndpointOverrides:         endpointOverrides,
+		}
+
+		// Export traces when an OTLP endpoint is set in the environment
+		if err := common.InitTracing(ctx, Version); err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		// Record or replay API traffic when running in VCR mode
+		if vcrMode := os.Getenv("PORTNOX_VCR_MODE"); vcrMode != "" {
+			transport, err := common.NewVCRTransport(vcrMode, os.Getenv("PORTNOX_VCR_CASSETTE"), apiKey)
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			config.Transport = transport
+		}
+
+		// Detect the API version once, so resources read responses in the format the tenant uses. It cannot be
+		// detected before the API key is known.
+		if apiKey == "" {
+			return config, nil
+		}
+		if err := config.DetectCapabilities(ctx); err != nil {
+			return config, diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Unable to detect the Portnox API version",
+				Detail:   fmt.Sprintf("The provider will detect the response format of each API response instead: %s", err),
+			}}
+		}
+
+		return config, nil
 	}
+
+	return p
 }
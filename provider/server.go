@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+	"github.com/portnox-community/terraform-provider-portnox/internal/providers"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerServer serves the SDK provider and adds the ephemeral resources and functions, which the plugin SDK cannot
+// express. All other calls are handled by the SDK.
+type providerServer struct {
+	tfprotov5.ProviderServer
+
+	provider           *schema.Provider
+	ephemeralResources map[string]*providers.EphemeralResource
+	functions          map[string]*providers.Function
+}
+
+// ProviderServer returns the plugin protocol server of the provider, including its ephemeral resources and functions
+func ProviderServer() tfprotov5.ProviderServer {
+	p := Provider()
+
+	return &providerServer{
+		ProviderServer: schema.NewGRPCProviderServer(p),
+		provider:       p,
+		ephemeralResources: map[string]*providers.EphemeralResource{
+			"portnox_api_token": providers.EphemeralApiToken(),
+		},
+		functions: map[string]*providers.Function{
+			"mac_in_oui": providers.FunctionMacInOui(),
+		},
+	}
+}
+
+func (s *providerServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := s.ProviderServer.GetMetadata(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	typeNames := make([]string, 0, len(s.ephemeralResources))
+	for typeName := range s.ephemeralResources {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+	for _, typeName := range typeNames {
+		resp.EphemeralResources = append(resp.EphemeralResources, tfprotov5.EphemeralResourceMetadata{TypeName: typeName})
+	}
+
+	names := make([]string, 0, len(s.functions))
+	for name := range s.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resp.Functions = append(resp.Functions, tfprotov5.FunctionMetadata{Name: name})
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.EphemeralResourceSchemas == nil {
+		resp.EphemeralResourceSchemas = make(map[string]*tfprotov5.Schema, len(s.ephemeralResources))
+	}
+	for typeName, resource := range s.ephemeralResources {
+		resp.EphemeralResourceSchemas[typeName] = resource.Schema
+	}
+
+	if resp.Functions == nil {
+		resp.Functions = make(map[string]*tfprotov5.Function, len(s.functions))
+	}
+	for name, function := range s.functions {
+		resp.Functions[name] = function.Definition
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) GetFunctions(ctx context.Context, req *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	resp, err := s.ProviderServer.GetFunctions(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.Functions == nil {
+		resp.Functions = make(map[string]*tfprotov5.Function, len(s.functions))
+	}
+	for name, function := range s.functions {
+		resp.Functions[name] = function.Definition
+	}
+
+	return resp, nil
+}
+
+func (s *providerServer) CallFunction(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	function, ok := s.functions[req.Name]
+	if !ok {
+		return s.ProviderServer.CallFunction(ctx, req)
+	}
+
+	parameters := function.Definition.Parameters
+	if len(req.Arguments) != len(parameters) {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{Text: fmt.Sprintf("%s takes %d arguments, got %d", req.Name, len(parameters), len(req.Arguments))},
+		}, nil
+	}
+
+	// Terraform converts the arguments to the parameter types and rejects null or unknown values before calling
+	arguments := make([]tftypes.Value, 0, len(req.Arguments))
+	for i, argument := range req.Arguments {
+		value, err := argument.Unmarshal(parameters[i].Type)
+		if err != nil {
+			position := int64(i)
+			return &tfprotov5.CallFunctionResponse{
+				Error: &tfprotov5.FunctionError{Text: "Invalid argument: " + err.Error(), FunctionArgument: &position},
+			}, nil
+		}
+		arguments = append(arguments, value)
+	}
+
+	result, functionErr := function.Call(ctx, arguments)
+	if functionErr != nil {
+		return &tfprotov5.CallFunctionResponse{Error: functionErr}, nil
+	}
+
+	resultValue, err := tfprotov5.NewDynamicValue(function.Definition.Return.Type, result)
+	if err != nil {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{Text: "Error encoding the result of " + req.Name + ": " + err.Error()},
+		}, nil
+	}
+
+	return &tfprotov5.CallFunctionResponse{Result: &resultValue}, nil
+}
+
+func (s *providerServer) ValidateEphemeralResourceConfig(ctx context.Context, req *tfprotov5.ValidateEphemeralResourceConfigRequest) (*tfprotov5.ValidateEphemeralResourceConfigResponse, error) {
+	if _, ok := s.ephemeralResources[req.TypeName]; !ok {
+		return s.ProviderServer.ValidateEphemeralResourceConfig(ctx, req)
+	}
+
+	// Terraform validates the configuration against the schema; values are checked when the resource is opened
+	return &tfprotov5.ValidateEphemeralResourceConfigResponse{}, nil
+}
+
+func (s *providerServer) OpenEphemeralResource(ctx context.Context, req *tfprotov5.OpenEphemeralResourceRequest) (*tfprotov5.OpenEphemeralResourceResponse, error) {
+	resource, ok := s.ephemeralResources[req.TypeName]
+	if !ok {
+		return s.ProviderServer.OpenEphemeralResource(ctx, req)
+	}
+
+	config, diagnostics := s.config()
+	if diagnostics != nil {
+		return &tfprotov5.OpenEphemeralResourceResponse{Diagnostics: diagnostics}, nil
+	}
+
+	objectType := resource.Schema.ValueType()
+	configValue, err := req.Config.Unmarshal(objectType)
+	if err != nil {
+		return &tfprotov5.OpenEphemeralResourceResponse{Diagnostics: errorDiagnostics("Invalid ephemeral resource configuration", err)}, nil
+	}
+	values := make(map[string]tftypes.Value)
+	if err := configValue.As(&values); err != nil {
+		return &tfprotov5.OpenEphemeralResourceResponse{Diagnostics: errorDiagnostics("Invalid ephemeral resource configuration", err)}, nil
+	}
+
+	resultValues, private, err := resource.Open(ctx, config, values)
+	if err != nil {
+		return &tfprotov5.OpenEphemeralResourceResponse{Diagnostics: errorDiagnostics("Error opening "+req.TypeName, err)}, nil
+	}
+
+	result, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, resultValues))
+	if err != nil {
+		return &tfprotov5.OpenEphemeralResourceResponse{Diagnostics: errorDiagnostics("Error encoding "+req.TypeName, err)}, nil
+	}
+
+	return &tfprotov5.OpenEphemeralResourceResponse{
+		Result:  &result,
+		Private: private,
+	}, nil
+}
+
+func (s *providerServer) RenewEphemeralResource(ctx context.Context, req *tfprotov5.RenewEphemeralResourceRequest) (*tfprotov5.RenewEphemeralResourceResponse, error) {
+	if _, ok := s.ephemeralResources[req.TypeName]; !ok {
+		return s.ProviderServer.RenewEphemeralResource(ctx, req)
+	}
+
+	// Ephemeral resources never request renewal, so there is nothing to renew
+	return &tfprotov5.RenewEphemeralResourceResponse{}, nil
+}
+
+func (s *providerServer) CloseEphemeralResource(ctx context.Context, req *tfprotov5.CloseEphemeralResourceRequest) (*tfprotov5.CloseEphemeralResourceResponse, error) {
+	resource, ok := s.ephemeralResources[req.TypeName]
+	if !ok {
+		return s.ProviderServer.CloseEphemeralResource(ctx, req)
+	}
+
+	if resource.Close == nil {
+		return &tfprotov5.CloseEphemeralResourceResponse{}, nil
+	}
+
+	config, diagnostics := s.config()
+	if diagnostics != nil {
+		return &tfprotov5.CloseEphemeralResourceResponse{Diagnostics: diagnostics}, nil
+	}
+
+	if err := resource.Close(ctx, config, req.Private); err != nil {
+		return &tfprotov5.CloseEphemeralResourceResponse{Diagnostics: errorDiagnostics("Error closing "+req.TypeName, err)}, nil
+	}
+
+	return &tfprotov5.CloseEphemeralResourceResponse{}, nil
+}
+
+// config returns the configured provider, or a diagnostic when the provider has not been configured yet
+func (s *providerServer) config() (*common.Config, []*tfprotov5.Diagnostic) {
+	config, ok := s.provider.Meta().(*common.Config)
+	if !ok || config == nil {
+		return nil, []*tfprotov5.Diagnostic{{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Provider not configured",
+			Detail:   "The Portnox provider must be configured before ephemeral resources can be opened.",
+		}}
+	}
+	return config, nil
+}
+
+// errorDiagnostics converts an error into plugin protocol diagnostics
+func errorDiagnostics(summary string, err error) []*tfprotov5.Diagnostic {
+	return []*tfprotov5.Diagnostic{{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  summary,
+		Detail:   err.Error(),
+	}}
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderServerMacInOui(t *testing.T) {
+	server := ProviderServer()
+
+	functions, err := server.GetFunctions(context.Background(), &tfprotov5.GetFunctionsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := functions.Functions["mac_in_oui"]; !ok {
+		t.Fatalf("GetFunctions does not return mac_in_oui: %v", functions.Functions)
+	}
+
+	prefixesType := tftypes.List{ElementType: tftypes.String}
+	cases := []struct {
+		name         string
+		mac          string
+		prefixes     []string
+		want         bool
+		wantArgument int64 // position of the argument the error is attributed to, -1 for no error
+	}{
+		{"match", "00:40:8C:12:34:56", []string{"AC:CC:8E", "00-40-8c"}, true, -1},
+		{"no match", "00:1B:A9:65:43:21", []string{"00:40:8C"}, false, -1},
+		{"bare notation", "00408c123456", []string{"00408C"}, true, -1},
+		{"no prefixes", "00:40:8C:12:34:56", []string{}, false, -1},
+		{"invalid mac", "00:40:8C", []string{"00:40:8C"}, false, 0},
+		{"invalid prefix", "00:40:8C:12:34:56", []string{"00:40:8C", "not-an-oui"}, false, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefixValues := make([]tftypes.Value, 0, len(tc.prefixes))
+			for _, prefix := range tc.prefixes {
+				prefixValues = append(prefixValues, tftypes.NewValue(tftypes.String, prefix))
+			}
+			mac, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, tc.mac))
+			if err != nil {
+				t.Fatal(err)
+			}
+			prefixes, err := tfprotov5.NewDynamicValue(prefixesType, tftypes.NewValue(prefixesType, prefixValues))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := server.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{
+				Name:      "mac_in_oui",
+				Arguments: []*tfprotov5.DynamicValue{&mac, &prefixes},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.wantArgument >= 0 {
+				if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != tc.wantArgument {
+					t.Fatalf("got error %+v, want an error on argument %d", resp.Error, tc.wantArgument)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error.Text)
+			}
+
+			result, err := resp.Result.Unmarshal(tftypes.Bool)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got bool
+			if err := result.As(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("mac_in_oui(%q, %v) = %t, want %t", tc.mac, tc.prefixes, got, tc.want)
+			}
+		})
+	}
+}
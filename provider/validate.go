@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/portnox-community/terraform-provider-portnox/common"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// attributeError returns an error diagnostic scoped to a provider attribute, so Terraform points at the offending
+// line of the provider block
+func attributeError(attribute, summary, detail string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       summary,
+		Detail:        detail,
+		AttributePath: cty.GetAttrPath(attribute),
+	}
+}
+
+// normalizeBaseURL checks that the base URL is an absolute https URL and returns it without trailing slashes, which
+// would otherwise produce paths such as //api/... that the API rejects with a confusing not-found error
+func normalizeBaseURL(baseURL string) (string, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid URL: %s", baseURL, err)
+	}
+	if parsed.Scheme != "https" {
+		return "", fmt.Errorf("%q must use https", baseURL)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("%q does not include a host", baseURL)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("%q must not include a query string or fragment", baseURL)
+	}
+	return strings.TrimRight(baseURL, "/"), nil
+}
+
+// apiKeyUnknown reports whether the API key is not known yet, as when a provider alias is configured with the key of
+// a portnox_child_organization created in the same apply. Terraform configures such a provider during plan with the
+// key unknown, and again with the key once it is known.
+func apiKeyUnknown(d *schema.ResourceData) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute("api_key") {
+		return false
+	}
+	return !raw.GetAttr("api_key").IsKnown()
+}
+
+// validateProviderConfig checks the provider settings when the provider is configured, rather than at validation
+// time, so values that come from variables or other resources are checked too. Each problem is reported against
+// its attribute instead of surfacing as a confusing error from the first API call.
+func validateProviderConfig(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d.Get("api_key").(string) == "" && !apiKeyUnknown(d) {
+		diags = append(diags, attributeError("api_key", "API key must be provided",
+			"Set api_key in the provider block or the TF_VAR_PORTNOX_API_KEY environment variable."))
+	}
+
+	if _, err := normalizeBaseURL(d.Get("base_url").(string)); err != nil {
+		diags = append(diags, attributeError("base_url", "Invalid base_url",
+			fmt.Sprintf("base_url must be an https URL such as https://clear.portnox.com:8081/CloudPortalBackEnd: %s.", err)))
+	}
+
+	for _, attribute := range []string{"retries", "read_retries", "write_retries", "max_backoff", "max_retry_elapsed_time", "whitelist_batch_window_ms", "circuit_breaker_threshold"} {
+		if value := d.Get(attribute).(int); value < 0 {
+			diags = append(diags, attributeError(attribute, "Invalid "+attribute,
+				fmt.Sprintf("%s must be 0 or greater, got %d.", attribute, value)))
+		}
+	}
+
+	if value := d.Get("whitelist_chunk_size").(int); value <= 0 {
+		diags = append(diags, attributeError("whitelist_chunk_size", "Invalid whitelist_chunk_size",
+			fmt.Sprintf("whitelist_chunk_size must be greater than 0, got %d.", value)))
+	}
+
+	if value := d.Get("retry_interval").(int); value <= 0 {
+		diags = append(diags, attributeError("retry_interval", "Invalid retry_interval",
+			fmt.Sprintf("retry_interval must be greater than 0 seconds, got %d.", value)))
+	}
+	// The read and write intervals default to retry_interval when unset, which the SDK reports as 0
+	for _, attribute := range []string{"read_retry_interval", "write_retry_interval"} {
+		if value := d.Get(attribute).(int); value < 0 {
+			diags = append(diags, attributeError(attribute, "Invalid "+attribute,
+				fmt.Sprintf("%s must be greater than 0 seconds, got %d.", attribute, value)))
+		}
+	}
+
+	if d.Get("circuit_breaker_threshold").(int) > 0 {
+		if value := d.Get("circuit_breaker_cooldown").(int); value <= 0 {
+			diags = append(diags, attributeError("circuit_breaker_cooldown", "Invalid circuit_breaker_cooldown",
+				fmt.Sprintf("circuit_breaker_cooldown must be greater than 0 seconds while the circuit breaker is enabled, got %d.", value)))
+		}
+	}
+
+	return diags
+}
+
+// endpointsSchema has one optional attribute per overridable API path prefix
+func endpointsSchema() map[string]*schema.Schema {
+	endpoints := make(map[string]*schema.Schema, len(common.EndpointBases))
+	for attribute, base := range common.EndpointBases {
+		endpoints[attribute] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Replaces the %s path prefix, e.g. with %s.", base, strings.Replace(base, "/api/", "/api/v2/", 1)),
+		}
+	}
+	return endpoints
+}
+
+// expandEndpoints converts the endpoints block into the path prefix overrides, keyed by the default prefix
+func expandEndpoints(list []interface{}) (map[string]string, diag.Diagnostics) {
+	if len(list) == 0 || list[0] == nil {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	overrides := make(map[string]string)
+	for attribute, value := range list[0].(map[string]interface{}) {
+		override, _ := value.(string)
+		if override == "" {
+			continue
+		}
+		if !strings.HasPrefix(override, "/") || strings.ContainsAny(override, "?#") {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid endpoints." + attribute,
+				Detail:        fmt.Sprintf("%s must be a path relative to base_url starting with /, such as %s, got %q.", attribute, strings.Replace(common.EndpointBases[attribute], "/api/", "/api/v2/", 1), override),
+				AttributePath: cty.GetAttrPath("endpoints").IndexInt(0).GetAttr(attribute),
+			})
+			continue
+		}
+		overrides[common.EndpointBases[attribute]] = override
+	}
+
+	return overrides, diags
+}